@@ -0,0 +1,273 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// ErrQueueFull é retornado por NotifyAsync quando a fila de envio assíncrono está cheia
+var ErrQueueFull = errors.New("fila de envio assíncrono cheia")
+
+// asyncItem é uma notificação aguardando envio na fila assíncrona
+type asyncItem struct {
+	ctx      context.Context
+	params   *Data
+	enqueued time.Time
+}
+
+// asyncQueue é a fila de prioridade do envio assíncrono, com uma fila FIFO por severidade
+type asyncQueue struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// Filas indexadas pela posição da severidade em Severities(), da menos para a mais urgente
+	levels [][]*asyncItem
+	size   int
+
+	// Notificações retiradas da fila e ainda em envio
+	inFlight int
+
+	// Indica que a última notificação retirada passou à frente de uma mais urgente
+	promoted bool
+
+	closed  bool
+	workers sync.Once
+}
+
+// newAsyncQueue cria uma fila vazia
+func newAsyncQueue() *asyncQueue {
+	q := &asyncQueue{levels: make([][]*asyncItem, len(Severities()))}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push adiciona uma notificação à fila da sua severidade
+func (q *asyncQueue) push(item *asyncItem, capacity int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return fmt.Errorf("não é possível enviar notificações por um cliente fechado")
+	}
+	if q.size >= capacity {
+		return ErrQueueFull
+	}
+
+	level := slices.Index(Severities(), item.params.severity())
+	if level < 0 {
+		return fmt.Errorf("invalid severity: %s", item.params.severity())
+	}
+	q.levels[level] = append(q.levels[level], item)
+	q.size++
+	q.cond.Broadcast()
+	return nil
+}
+
+// pop retira a próxima notificação, aguardando se a fila estiver vazia. Retorna nil quando
+// a fila foi fechada e esvaziada. A notificação mais urgente sai primeiro, exceto quando
+// uma menos urgente espera há mais de maxWait: nesse caso, a que espera há mais tempo sai antes,
+// alternando com as mais urgentes para que um acúmulo antigo não as bloqueie.
+func (q *asyncQueue) pop(maxWait func() time.Duration) *asyncItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.size == 0 {
+		if q.closed {
+			return nil
+		}
+		q.cond.Wait()
+	}
+
+	next := -1
+	for level := len(q.levels) - 1; level >= 0; level-- {
+		if len(q.levels[level]) > 0 {
+			next = level
+			break
+		}
+	}
+
+	// Proteção contra starvation: as filas menos urgentes também são atendidas sob carga
+	highest := next
+	if wait := maxWait(); wait > 0 && !q.promoted {
+		oldest := q.levels[next][0].enqueued
+		for level := next - 1; level >= 0; level-- {
+			if len(q.levels[level]) == 0 {
+				continue
+			}
+			if enqueued := q.levels[level][0].enqueued; time.Since(enqueued) >= wait && enqueued.Before(oldest) {
+				next, oldest = level, enqueued
+			}
+		}
+	}
+
+	q.promoted = next != highest
+
+	item := q.levels[next][0]
+	q.levels[next][0] = nil
+	q.levels[next] = q.levels[next][1:]
+	q.size--
+	q.inFlight++
+	return item
+}
+
+// done marca o fim do envio de uma notificação retirada por pop
+func (q *asyncQueue) done() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.inFlight--
+	q.cond.Broadcast()
+}
+
+// wait aguarda a fila esvaziar e os envios em andamento terminarem, ou o contexto ser cancelado
+func (q *asyncQueue) wait(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.cond.Broadcast()
+	})
+	defer stop()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.size > 0 || q.inFlight > 0 {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%d notificações assíncronas pendentes: %w", q.size+q.inFlight, ctx.Err())
+		}
+		q.cond.Wait()
+	}
+	return nil
+}
+
+// close impede novos envios; os workers terminam depois de esvaziar a fila
+func (q *asyncQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// discard remove as notificações que ainda não foram enviadas e retorna quantas foram descartadas
+func (q *asyncQueue) discard() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	discarded := q.size
+	for level := range q.levels {
+		q.levels[level] = nil
+	}
+	q.size = 0
+	q.cond.Broadcast()
+	return discarded
+}
+
+// depth retorna a quantidade de notificações na fila de cada severidade
+func (q *asyncQueue) depth() map[string]int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	depth := make(map[string]int, len(q.levels))
+	for level, severity := range Severities() {
+		depth[severity] = len(q.levels[level])
+	}
+	return depth
+}
+
+// NotifyAsync valida a notificação e a coloca na fila de envio assíncrono, retornando sem esperar
+// o servidor. A fila é ordenada pela severidade: notificações críticas são enviadas antes das
+// demais, e as menos urgentes que esperam há mais de AsyncMaxWait passam à frente para não
+// ficarem paradas sob carga. Retorna ErrQueueFull quando a fila atinge AsyncQueueSize.
+//
+// Assim como em NotifyWithResult, os campos não informados são preenchidos pelo contexto.
+// O envio usa os valores do contexto, mas não é cancelado junto com ele. Erros de envio são
+// enviados ao Sentry; use Flush para aguardar o envio das notificações pendentes.
+func (c *NotifyClient) NotifyAsync(ctx context.Context, params *Data) error {
+	if params == nil {
+		return fmt.Errorf("parâmetros de notificação não podem ser nulos")
+	}
+	params, err := params.fromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := c.state.Load()
+
+	// Na cadeia padrão, notificações inválidas são rejeitadas já ao enfileirar; com
+	// WithMiddlewareChain, a validação fica a cargo da cadeia, pois um middleware pode
+	// preencher os campos ausentes. A severidade é verificada mesmo assim, pois define a fila
+	// da notificação; sem ela, é usada a severidade padrão do tipo.
+	validate := params.Validate
+	if state.options.CustomChain {
+		validate = params.validateSeverity
+	}
+	if err := validate(); err != nil {
+		return fmt.Errorf("parâmetros inválidos: %w", err)
+	}
+
+	// Copia os parâmetros para que o chamador possa reutilizá-los enquanto a notificação espera
+	now := time.Now()
+	item := &asyncItem{
+		ctx:      context.WithoutCancel(ctx),
+		params:   params.clone().stamp(now),
+		enqueued: now,
+	}
+	c.async.workers.Do(func() {
+		for range state.options.AsyncWorkers {
+			go c.asyncWorker()
+		}
+	})
+	return c.async.push(item, state.options.AsyncQueueSize)
+}
+
+// Flush aguarda o envio de todas as notificações enfileiradas por NotifyAsync,
+// ou o cancelamento do contexto
+func (c *NotifyClient) Flush(ctx context.Context) error {
+	return c.async.wait(ctx)
+}
+
+// QueueDepth retorna quantas notificações aguardam envio assíncrono em cada severidade
+func (c *NotifyClient) QueueDepth() map[string]int {
+	return c.async.depth()
+}
+
+// asyncWorker envia as notificações da fila assíncrona até ela ser fechada e esvaziada
+func (c *NotifyClient) asyncWorker() {
+	maxWait := func() time.Duration {
+		return c.state.Load().options.AsyncMaxWait
+	}
+
+	for {
+		item := c.async.pop(maxWait)
+		if item == nil {
+			return
+		}
+
+		if _, err := c.handle(item.ctx, c.state.Load(), item.params); err != nil {
+			// Captura erro no Sentry, se configurado
+			sentry.CaptureException(fmt.Errorf("falha no envio assíncrono (espera de %s na fila): %w", time.Since(item.enqueued).Round(time.Millisecond), err))
+		}
+		c.async.done()
+	}
+}
+
+// closeAsync fecha a fila assíncrona e aguarda até timeout o envio das notificações pendentes.
+// As que não forem enviadas a tempo são descartadas e reportadas ao Sentry.
+func (c *NotifyClient) closeAsync(timeout time.Duration) {
+	c.async.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := c.async.wait(ctx); err != nil {
+		if discarded := c.async.discard(); discarded > 0 {
+			sentry.CaptureException(fmt.Errorf("%d notificações assíncronas descartadas ao fechar o cliente: %w", discarded, err))
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+)
+
+// blockingServer retorna um servidor que segura a notificação do projeto "bloqueio" até release
+// ser fechado, e um canal que recebe um valor quando ela chega. Com um único worker, as
+// notificações enfileiradas depois dela ficam na fila.
+func blockingServer(t *testing.T) (server *fakeServer, started <-chan struct{}, release func()) {
+	t.Helper()
+
+	arrived := make(chan struct{}, 1)
+	gate := make(chan struct{})
+	server = &fakeServer{notify: func(ctx context.Context, req *notifications.NotifyRequest) error {
+		if req.ProjectId == "bloqueio" {
+			arrived <- struct{}{}
+			<-gate
+		}
+		return nil
+	}}
+
+	var released bool
+	release = func() {
+		if !released {
+			released = true
+			close(gate)
+		}
+	}
+	return server, arrived, release
+}
+
+// asyncData retorna uma notificação válida com a severidade informada
+func asyncData(projectID, severity string) *Data {
+	params := testData(projectID)
+	params.Severity = severity
+	return params
+}
+
+func TestNotifyAsyncSeverityOrder(t *testing.T) {
+	server, started, release := blockingServer(t)
+	c := newTestClient(t, server, WithAsyncWorkers(1))
+	t.Cleanup(release)
+
+	if err := c.NotifyAsync(context.Background(), testData("bloqueio")); err != nil {
+		t.Fatalf("NotifyAsync: %v", err)
+	}
+	<-started
+
+	for _, params := range []*Data{
+		asyncData("info-1", INFO),
+		asyncData("warning-1", WARNING),
+		asyncData("critical-1", CRITICAL),
+		asyncData("info-2", INFO),
+		asyncData("critical-2", CRITICAL),
+	} {
+		if err := c.NotifyAsync(context.Background(), params); err != nil {
+			t.Fatalf("NotifyAsync(%s): %v", params.ProjectID, err)
+		}
+	}
+
+	depth := c.QueueDepth()
+	if depth[CRITICAL] != 2 || depth[WARNING] != 1 || depth[INFO] != 2 {
+		t.Errorf("QueueDepth = %v, esperado 2 CRITICAL, 1 WARNING e 2 INFO", depth)
+	}
+
+	release()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	var order []string
+	for _, req := range server.received() {
+		order = append(order, req.ProjectId)
+	}
+	want := []string{"bloqueio", "critical-1", "critical-2", "warning-1", "info-1", "info-2"}
+	if !slices.Equal(order, want) {
+		t.Errorf("ordem de envio %v, esperado %v", order, want)
+	}
+	if depth := c.QueueDepth(); depth[CRITICAL]+depth[WARNING]+depth[INFO] != 0 {
+		t.Errorf("QueueDepth = %v após Flush, esperado fila vazia", depth)
+	}
+}
+
+func TestAsyncQueueMaxWait(t *testing.T) {
+	old := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name    string
+		maxWait time.Duration
+		want    []string
+	}{
+		// As notificações INFO antigas passam à frente, alternando com as críticas
+		{"com espera máxima", 30 * time.Second, []string{"info-1", "critical-1", "info-2", "critical-2"}},
+		{"sem espera máxima", 0, []string{"critical-1", "critical-2", "info-1", "info-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newAsyncQueue()
+			for _, item := range []*asyncItem{
+				{params: asyncData("info-1", INFO), enqueued: old},
+				{params: asyncData("info-2", INFO), enqueued: old.Add(time.Second)},
+				{params: asyncData("critical-1", CRITICAL), enqueued: time.Now()},
+				{params: asyncData("critical-2", CRITICAL), enqueued: time.Now()},
+			} {
+				if err := q.push(item, 10); err != nil {
+					t.Fatalf("push: %v", err)
+				}
+			}
+
+			var order []string
+			for range 4 {
+				order = append(order, q.pop(func() time.Duration { return tt.maxWait }).params.ProjectID)
+				q.done()
+			}
+			if !slices.Equal(order, tt.want) {
+				t.Errorf("ordem %v, esperado %v", order, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotifyAsyncQueueFull(t *testing.T) {
+	server, started, release := blockingServer(t)
+	c := newTestClient(t, server, WithAsyncWorkers(1), WithAsyncQueueSize(2))
+	t.Cleanup(release)
+
+	if err := c.NotifyAsync(context.Background(), testData("bloqueio")); err != nil {
+		t.Fatalf("NotifyAsync: %v", err)
+	}
+	<-started
+
+	for i := range 2 {
+		if err := c.NotifyAsync(context.Background(), testData("p1")); err != nil {
+			t.Fatalf("envio %d: %v", i, err)
+		}
+	}
+	if err := c.NotifyAsync(context.Background(), asyncData("p1", CRITICAL)); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("NotifyAsync com a fila cheia: %v, esperado ErrQueueFull", err)
+	}
+
+	// Flush respeita o cancelamento do contexto enquanto há envios pendentes
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Flush: %v, esperado context.DeadlineExceeded", err)
+	}
+}
+
+func TestNotifyAsyncValidatesOnEnqueue(t *testing.T) {
+	c := newTestClient(t, &fakeServer{})
+
+	if err := c.NotifyAsync(context.Background(), &Data{ProjectID: "p1", Scope: "OUTRO", Type: BOUNCE}); err == nil {
+		t.Error("notificação inválida aceita na fila")
+	}
+	if depth := c.QueueDepth(); depth[WARNING] != 0 {
+		t.Errorf("QueueDepth = %v, esperado fila vazia", depth)
+	}
+}
+
+func TestCloseDiscardsPendingAsync(t *testing.T) {
+	captured := captureSentry(t)
+	server, started, release := blockingServer(t)
+	c := newTestClient(t, server, WithAsyncWorkers(1), WithTimeout(100*time.Millisecond))
+	t.Cleanup(release)
+
+	if err := c.NotifyAsync(context.Background(), testData("bloqueio")); err != nil {
+		t.Fatalf("NotifyAsync: %v", err)
+	}
+	<-started
+	for range 3 {
+		if err := c.NotifyAsync(context.Background(), testData("p1")); err != nil {
+			t.Fatalf("NotifyAsync: %v", err)
+		}
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if depth := c.QueueDepth(); depth[WARNING] != 0 {
+		t.Errorf("QueueDepth = %v após Close, esperado fila vazia", depth)
+	}
+	if err := c.NotifyAsync(context.Background(), testData("p1")); err == nil {
+		t.Error("NotifyAsync aceito após Close")
+	}
+
+	var reported bool
+	for _, message := range captured() {
+		reported = reported || strings.Contains(message, "3 notificações assíncronas descartadas")
+	}
+	if !reported {
+		t.Errorf("descarte não reportado ao Sentry: %v", captured())
+	}
+}
+
+func TestNotifyAsyncCustomChainUnknownSeverity(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, WithMiddlewareChain(ValidationMiddleware()))
+
+	// Mesmo com a validação a cargo da cadeia, uma severidade desconhecida é rejeitada ao
+	// enfileirar, pois não há fila para ela
+	if err := c.NotifyAsync(context.Background(), asyncData("p1", "URGENTE")); err == nil || !strings.Contains(err.Error(), "invalid severity") {
+		t.Fatalf("NotifyAsync: %v, esperado erro de severidade", err)
+	}
+
+	if err := c.NotifyAsync(context.Background(), asyncData("p1", CRITICAL)); err != nil {
+		t.Fatalf("NotifyAsync: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := c.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if got := len(server.received()); got != 1 {
+		t.Errorf("servidor recebeu %d notificações, esperado 1", got)
+	}
+}
+
+func TestAsyncQueueUnknownSeverity(t *testing.T) {
+	q := newAsyncQueue()
+	if err := q.push(&asyncItem{params: asyncData("p1", "URGENTE")}, 10); err == nil {
+		t.Error("push aceitou uma severidade desconhecida")
+	}
+	if q.size != 0 {
+		t.Errorf("fila com %d notificações, esperado 0", q.size)
+	}
+}
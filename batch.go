@@ -0,0 +1,193 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// BatchResult é o resultado de uma notificação enviada por NotifyBatch
+type BatchResult struct {
+	// Posição da notificação no slice recebido por NotifyBatch
+	Index int
+
+	// Notificação criada; nil quando houve erro
+	Result *Result
+
+	// Erro de validação ou de envio; nil quando a notificação foi criada
+	Err error
+}
+
+// NotifyBatch envia várias notificações de uma vez através do RPC NotifyBatch.
+// Todas as notificações são validadas antes do envio; se alguma for inválida, o lote inteiro é
+// rejeitado sem enviar nenhuma, e as válidas recebem um erro indicando que não foram enviadas.
+// Os campos não informados são preenchidos pelo contexto, como em NotifyWithResult.
+// Com middlewares, dry run ou shadow configurados, as notificações também são enviadas por
+// chamadas unárias, para que cada uma passe pela cadeia.
+// Se o servidor não implementar NotifyBatch, as notificações são enviadas por chamadas
+// unárias em paralelo, limitadas por BatchConcurrency. O RPC em lote existe apenas no
+// serviço v1; as chamadas unárias usam o v2 quando o servidor o implementa.
+// Retorna um resultado por notificação, na mesma ordem, e um erro se alguma delas falhar.
+func (c *NotifyClient) NotifyBatch(ctx context.Context, items []*Data) ([]BatchResult, error) {
+	// Usa a mesma configuração durante todo o lote, mesmo que ela seja recarregada
+	state := c.state.Load()
+
+	results := make([]BatchResult, len(items))
+	stamped := make([]*Data, 0, len(items))
+	valid := make([]*Data, 0, len(items))
+	reqs := make([]*notifications.NotifyRequest, 0, len(items))
+	indexes := make([]int, 0, len(items))
+
+	// Com middlewares, dry run ou shadow, cada notificação passa pela cadeia em uma chamada unária
+	unary := c.batchUnsupported.Load() || state.unaryOnly()
+
+	// Valida tudo antes de enviar
+	now := time.Now()
+	for i, params := range items {
+		results[i].Index = i
+		if params == nil {
+			results[i].Err = fmt.Errorf("parâmetros de notificação não podem ser nulos")
+			continue
+		}
+		params, err := params.fromContext(ctx)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		params = params.stamp(now)
+
+		// Com WithMiddlewareChain, a validação fica a cargo da cadeia de cada envio
+		if !state.options.CustomChain {
+			if err := params.Validate(); err != nil {
+				results[i].Err = fmt.Errorf("parâmetros inválidos: %w", err)
+				continue
+			}
+		}
+		if unary {
+			stamped = append(stamped, params)
+			indexes = append(indexes, i)
+			continue
+		}
+
+		// Sem middlewares, a cadeia padrão se resume à validação e aos enrichers
+		prepared := enrich(ctx, params, state.options.Enrichers)
+		req, err := prepared.toGRPCRequest(state.options.Origin)
+		if err != nil {
+			results[i].Err = fmt.Errorf("parâmetros inválidos: %w", err)
+			continue
+		}
+		if err := state.options.checkEventAge(prepared); err != nil {
+			results[i].Err = err
+			continue
+		}
+		stamped = append(stamped, params)
+		valid = append(valid, prepared)
+		reqs = append(reqs, req)
+		indexes = append(indexes, i)
+	}
+
+	// Uma notificação inválida rejeita o lote inteiro, antes de qualquer envio
+	if invalid := countFailed(results); invalid > 0 {
+		for _, i := range indexes {
+			results[i].Err = fmt.Errorf("notificação não enviada: o lote contém %d notificações inválidas", invalid)
+		}
+		return results, fmt.Errorf("lote rejeitado: %d de %d notificações inválidas", invalid, len(items))
+	}
+
+	if len(indexes) > 0 {
+		if unary {
+			c.sendParallel(ctx, state, stamped, indexes, results)
+		} else if err := c.sendBatch(ctx, state, valid, reqs, indexes, results); status.Code(err) == codes.Unimplemented {
+			// Servidor sem suporte ao RPC em lote: usa chamadas unárias daqui em diante. As
+			// notificações são reenviadas sem os enrichers, que a cadeia executa novamente.
+			c.batchUnsupported.Store(true)
+			c.sendParallel(ctx, state, stamped, indexes, results)
+		}
+	}
+
+	if failed := countFailed(results); failed > 0 {
+		return results, fmt.Errorf("%d de %d notificações não foram enviadas", failed, len(items))
+	}
+	return results, nil
+}
+
+// countFailed retorna quantos resultados têm erro
+func countFailed(results []BatchResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// sendBatch envia as requisições em uma única chamada NotifyBatch e preenche os resultados.
+// O lote usa as retentativas da notificação mais urgente que contém.
+// Retorna o erro da chamada, que também é atribuído a todas as notificações do lote.
+func (c *NotifyClient) sendBatch(ctx context.Context, state *clientState, items []*Data, reqs []*notifications.NotifyRequest, indexes []int, results []BatchResult) error {
+	batch := &notifications.NotifyBatchRequest{Requests: reqs}
+
+	severity := INFO
+	for _, params := range items {
+		if slices.Index(Severities(), params.severity()) > slices.Index(Severities(), severity) {
+			severity = params.severity()
+		}
+	}
+
+	var resp *notifications.NotifyBatchResponse
+	attempts, err := c.callWithRetries(ctx, state, "enviar lote de notificações", state.options.maxRetries(severity), func(ctx context.Context, p *peer.Peer) error {
+		var err error
+		resp, err = state.client.NotifyBatch(ctx, batch, state.options.callOptions(batch, p)...)
+		return err
+	})
+	if err == nil && len(resp.GetResults()) != len(reqs) {
+		err = fmt.Errorf("resposta do lote com %d resultados para %d notificações", len(resp.GetResults()), len(reqs))
+	}
+	if err != nil {
+		for _, i := range indexes {
+			results[i].Err = err
+		}
+		return err
+	}
+
+	for j, result := range resp.GetResults() {
+		if result.GetError() != "" {
+			results[indexes[j]].Err = fmt.Errorf("notificação rejeitada pelo servidor: %s", result.GetError())
+			continue
+		}
+		results[indexes[j]].Result = newResult(result.GetResponse(), attempts)
+	}
+	return nil
+}
+
+// sendParallel envia as notificações por chamadas unárias, limitadas por BatchConcurrency
+func (c *NotifyClient) sendParallel(ctx context.Context, state *clientState, items []*Data, indexes []int, results []BatchResult) {
+	sem := make(chan struct{}, state.options.BatchConcurrency)
+	var wg sync.WaitGroup
+
+	for j, params := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[indexes[j]].Result, results[indexes[j]].Err = c.handle(ctx, state, params)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// unaryOnly indica se as notificações de NotifyBatch precisam passar pela cadeia de
+// middlewares em chamadas unárias, em vez do RPC em lote
+func (s *clientState) unaryOnly() bool {
+	return len(s.options.Middlewares) > 0 || s.options.CustomChain || s.options.DryRun || s.shadow != nil
+}
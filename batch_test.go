@@ -0,0 +1,172 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+)
+
+// batchServer implementa o RPC NotifyBatch, registrando cada lote recebido. As notificações
+// do projeto "rejeitado" são recusadas individualmente.
+type batchServer struct {
+	fakeServer
+
+	batchMu sync.Mutex
+	batches [][]*notifications.NotifyRequest
+}
+
+func (s *batchServer) NotifyBatch(ctx context.Context, req *notifications.NotifyBatchRequest) (*notifications.NotifyBatchResponse, error) {
+	s.batchMu.Lock()
+	s.batches = append(s.batches, req.GetRequests())
+	s.batchMu.Unlock()
+
+	resp := &notifications.NotifyBatchResponse{}
+	for _, r := range req.GetRequests() {
+		if r.GetProjectId() == "rejeitado" {
+			resp.Results = append(resp.Results, &notifications.NotifyBatchResult{Error: "projeto rejeitado"})
+			continue
+		}
+		resp.Results = append(resp.Results, &notifications.NotifyBatchResult{Response: &notifications.NotifyResponse{Id: "id-" + r.GetProjectId()}})
+	}
+	return resp, nil
+}
+
+// receivedBatches retorna os lotes recebidos até o momento
+func (s *batchServer) receivedBatches() [][]*notifications.NotifyRequest {
+	s.batchMu.Lock()
+	defer s.batchMu.Unlock()
+	return append([][]*notifications.NotifyRequest(nil), s.batches...)
+}
+
+// batchData retorna uma notificação válida para cada projeto informado
+func batchData(projectIDs ...string) []*Data {
+	items := make([]*Data, len(projectIDs))
+	for i, projectID := range projectIDs {
+		items[i] = testData(projectID)
+	}
+	return items
+}
+
+// checkBatchIDs verifica se cada resultado traz o ID da notificação na mesma posição da entrada
+func checkBatchIDs(t *testing.T, results []BatchResult, want ...string) {
+	t.Helper()
+
+	if len(results) != len(want) {
+		t.Fatalf("%d resultados, esperado %d", len(results), len(want))
+	}
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("resultado %d com Index %d", i, result.Index)
+		}
+		if want[i] == "" {
+			continue
+		}
+		if result.Err != nil || result.Result == nil || result.Result.ID != want[i] {
+			t.Errorf("resultado %d: %+v, esperado ID %s", i, result, want[i])
+		}
+	}
+}
+
+func TestNotifyBatchRPC(t *testing.T) {
+	server := &batchServer{}
+	c := newTestClient(t, server)
+
+	results, err := c.NotifyBatch(context.Background(), batchData("p1", "p2", "p3"))
+	if err != nil {
+		t.Fatalf("NotifyBatch: %v", err)
+	}
+	checkBatchIDs(t, results, "id-p1", "id-p2", "id-p3")
+
+	batches := server.receivedBatches()
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Fatalf("lotes recebidos: %d, esperado 1 com 3 notificações", len(batches))
+	}
+	for i, req := range batches[0] {
+		if want := []string{"p1", "p2", "p3"}[i]; req.GetProjectId() != want || req.GetOrigin() != "test" {
+			t.Errorf("requisição %d: projeto %q, origem %q", i, req.GetProjectId(), req.GetOrigin())
+		}
+	}
+	if calls := server.calls.Load(); calls != 0 {
+		t.Errorf("%d chamadas unárias, esperado 0", calls)
+	}
+}
+
+func TestNotifyBatchServerRejection(t *testing.T) {
+	c := newTestClient(t, &batchServer{})
+
+	results, err := c.NotifyBatch(context.Background(), batchData("p1", "rejeitado", "p3"))
+	if err == nil || !strings.Contains(err.Error(), "1 de 3") {
+		t.Errorf("NotifyBatch: %v, esperado 1 de 3 não enviadas", err)
+	}
+	checkBatchIDs(t, results, "id-p1", "", "id-p3")
+	if results[1].Err == nil || results[1].Result != nil {
+		t.Errorf("resultado rejeitado pelo servidor: %+v", results[1])
+	}
+}
+
+func TestNotifyBatchUnimplementedFallback(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, WithBatchConcurrency(2))
+
+	projects := []string{"p1", "p2", "p3", "p4", "p5"}
+	results, err := c.NotifyBatch(context.Background(), batchData(projects...))
+	if err != nil {
+		t.Fatalf("NotifyBatch: %v", err)
+	}
+	checkBatchIDs(t, results, "id-p1", "id-p2", "id-p3", "id-p4", "id-p5")
+	if got := len(server.received()); got != 5 {
+		t.Errorf("servidor recebeu %d notificações unárias, esperado 5", got)
+	}
+	if !c.batchUnsupported.Load() {
+		t.Error("o cliente não registrou a falta de suporte ao RPC em lote")
+	}
+
+	// Os lotes seguintes vão direto para as chamadas unárias
+	results, err = c.NotifyBatch(context.Background(), batchData("p6", "p7"))
+	if err != nil {
+		t.Fatalf("NotifyBatch: %v", err)
+	}
+	checkBatchIDs(t, results, "id-p6", "id-p7")
+	if got := len(server.received()); got != 7 {
+		t.Errorf("servidor recebeu %d notificações unárias, esperado 7", got)
+	}
+}
+
+func TestNotifyBatchRejectsInvalidUpfront(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		server *batchServer
+		opts   []Option
+	}{
+		{"RPC em lote", &batchServer{}, nil},
+		{"chamadas unárias", &batchServer{}, []Option{WithMiddleware(func(next Handler) Handler { return next })}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.server, tt.opts...)
+
+			items := batchData("p1", "p2", "p3")
+			items[1].Scope = "OUTRO"
+			items[2] = nil
+			results, err := c.NotifyBatch(context.Background(), items)
+			if err == nil || !strings.Contains(err.Error(), "lote rejeitado") {
+				t.Errorf("NotifyBatch: %v, esperado lote rejeitado", err)
+			}
+
+			if len(tt.server.receivedBatches()) != 0 || tt.server.calls.Load() != 0 {
+				t.Errorf("o servidor recebeu notificações de um lote inválido")
+			}
+			if results[0].Err == nil || !strings.Contains(results[0].Err.Error(), "não enviada") {
+				t.Errorf("notificação válida: %v, esperado erro de não envio", results[0].Err)
+			}
+			if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "invalid scope") {
+				t.Errorf("notificação inválida: %v, esperado erro de validação", results[1].Err)
+			}
+			if results[2].Err == nil {
+				t.Error("notificação nula aceita")
+			}
+		})
+	}
+}
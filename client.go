@@ -2,109 +2,405 @@ package notify
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/AdSeleto/notify/pb/notifications"
+	notificationsv2 "github.com/AdSeleto/notify/pb/notifications/v2"
 	"github.com/getsentry/sentry-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip" // registra o compressor gzip
+	_ "google.golang.org/grpc/health"        // habilita o health check do lado do cliente
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
 )
 
+// ErrClientClosed indica que a operação foi encerrada porque o cliente foi fechado
+var ErrClientClosed = errors.New("cliente de notificações fechado")
+
 // NotifyClient é a estrutura concreta para o cliente de notificações
 type NotifyClient struct {
-	conn    *grpc.ClientConn
-	client  notifications.NotificationsServiceClient
-	options *ClientOptions
+	// Conexão, cliente gRPC e opções em uso; trocados atomicamente por Reload
+	state atomic.Pointer[clientState]
+
+	// Serializa as chamadas de Reload e Close
+	mu     sync.Mutex
+	closed chan struct{}
+
+	// Último endpoint que respondeu a uma chamada
+	activeEndpoint atomic.Value
+
+	// Indica que o servidor não implementa NotifyBatch
+	batchUnsupported atomic.Bool
+
+	// Indica que o servidor não implementa o serviço v2
+	v2Unsupported atomic.Bool
+
+	// Fila de prioridade de NotifyAsync
+	async *asyncQueue
+
+	// Envios ao servidor shadow em andamento, limitados por ShadowConcurrency
+	shadows        sync.WaitGroup
+	shadowInFlight atomic.Int64
+
+	// Indica que as tentativas que falham não são enviadas ao Sentry; usado pelo cliente
+	// shadow, cujas falhas aparecem apenas na comparação com o servidor principal
+	quiet bool
+
+	// Opções informadas na criação do cliente, aplicadas antes e depois do arquivo
+	// quando a configuração é recarregada por WatchConfigFile
+	baseOpts     []Option
+	overrideOpts []Option
+}
+
+// clientState agrupa o que é substituído em conjunto quando a configuração é recarregada
+type clientState struct {
+	conn     *grpc.ClientConn
+	client   notifications.NotificationsServiceClient
+	clientV2 notificationsv2.NotificationsServiceClient
+	options  *ClientOptions
+
+	// Cliente do servidor shadow; nil quando ShadowAddress não está configurado
+	shadow *NotifyClient
+
+	// Failover por health check de PICK_FIRST; nil com um único endereço ou com ROUND_ROBIN
+	failover *healthFailover
+
+	// Data de modificação do certificado TLS usado na conexão
+	tlsModTime time.Time
 }
 
 // NewClient cria uma nova instância do cliente de notificações
 // Retorna um ponteiro para NotifyClient
 func NewClient(opts ...Option) (*NotifyClient, error) {
-	// Aplica as opções padrão
-	options := DefaultOptions()
+	// Aplica as opções padrão e as personalizadas
+	options := applyOptions(opts)
 
-	// Aplica as opções personalizadas
-	for _, opt := range opts {
-		opt(options)
+	// Verifica todas as opções de uma vez
+	if err := options.Validate(); err != nil {
+		return nil, err
 	}
 
-	// Verifica se origin foi configurado
-	if options.Origin == "" {
-		return nil, fmt.Errorf("a origem (Origin) do serviço deve ser configurada usando WithOrigin()")
+	state, err := newClientState(options)
+	if err != nil {
+		return nil, err
 	}
 
-	// Verifica se ServerAddress foi configurado
-	if options.ServerAddress == "" {
-		return nil, fmt.Errorf("o endereço do servidor (ServerAddress) deve ser configurado explicitamente usando WithServerAddress()")
+	c := &NotifyClient{
+		closed:   make(chan struct{}),
+		async:    newAsyncQueue(),
+		baseOpts: slices.Clip(opts),
+	}
+	c.state.Store(state)
+	if options.InProcessServer != nil {
+		c.setActiveEndpoint(options, inProcessEndpoint)
 	}
+	return c, nil
+}
 
-	// Estabelece a conexão gRPC
-	conn, err := createConnection(options)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao criar conexão gRPC: %w", err)
+// newClientState cria a conexão e o cliente gRPC para as opções informadas
+func newClientState(options *ClientOptions) (*clientState, error) {
+	var state *clientState
+
+	if options.InProcessServer != nil {
+		// Com um servidor em processo, não há conexão de rede
+		// O servidor em processo implementa apenas o serviço v1, então o v2 nem é tentado
+		conn := newInProcessConn(options.InProcessServer, options.UnaryInterceptors)
+		state = &clientState{
+			client:  notifications.NewNotificationsServiceClient(conn),
+			options: options,
+		}
+	} else {
+		tlsModTime := options.tlsModTime()
+
+		// Estabelece a conexão gRPC
+		conn, failover, err := createConnection(options)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao criar conexão gRPC: %w", err)
+		}
+
+		// Cria os clientes gRPC das duas versões do serviço
+		state = &clientState{
+			conn:       conn,
+			client:     notifications.NewNotificationsServiceClient(conn),
+			clientV2:   notificationsv2.NewNotificationsServiceClient(conn),
+			options:    options,
+			failover:   failover,
+			tlsModTime: tlsModTime,
+		}
 	}
 
-	// Cria o cliente gRPC
-	client := notifications.NewNotificationsServiceClient(conn)
+	if options.ShadowAddress != "" {
+		shadow, err := newShadowClient(options)
+		if err != nil {
+			state.close()
+			return nil, err
+		}
+		state.shadow = shadow
+	}
+	return state, nil
+}
 
-	return &NotifyClient{
-		conn:    conn,
-		client:  client,
-		options: options,
-	}, nil
+// close fecha a conexão do estado, a do servidor shadow e as de health check
+func (s *clientState) close() error {
+	if s.shadow != nil {
+		s.shadow.Close()
+	}
+	if s.failover != nil {
+		s.failover.close()
+	}
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
 }
 
 // Notify envia uma notificação através do serviço gRPC
 func (c *NotifyClient) Notify(ctx context.Context, params *Data) error {
+	_, err := c.NotifyWithResult(ctx, params)
+	return err
+}
+
+// NotifyWithResult envia uma notificação e retorna o ID e a data de criação atribuídos
+// pelo servidor, além do número de tentativas usadas.
+// ProjectID, Scope e Metadata não informados são preenchidos com os valores de WithProject,
+// WithScope e WithMetadata no contexto; valores que diferem do contexto retornam ErrContextConflict.
+func (c *NotifyClient) NotifyWithResult(ctx context.Context, params *Data) (*Result, error) {
 	if params == nil {
-		return fmt.Errorf("parâmetros de notificação não podem ser nulos")
+		return nil, fmt.Errorf("parâmetros de notificação não podem ser nulos")
+	}
+
+	// Usa a mesma configuração durante todas as tentativas, mesmo que ela seja recarregada
+	state := c.state.Load()
+
+	params, err := params.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A validação e os enrichers são passos da cadeia de middlewares, executados antes de
+	// qualquer tentativa de envio
+	return c.handle(ctx, state, params.stamp(time.Now()))
+}
+
+// deliver é o último passo da cadeia de middlewares: registra a notificação no modo dry run
+// ou a envia, também ao servidor shadow quando configurado
+func (c *NotifyClient) deliver(ctx context.Context, state *clientState, params *Data) (*Result, error) {
+	if state.options.DryRun {
+		return c.dryRun(ctx, state, params)
+	}
+	if state.shadow != nil {
+		return c.sendWithShadow(ctx, state, params)
+	}
+	return c.send(ctx, state, params)
+}
+
+// send envia uma notificação já validada pelo serviço v2. Se o servidor não implementar o v2,
+// a notificação é reenviada pelo v1, que passa a ser usado diretamente nas próximas chamadas.
+func (c *NotifyClient) send(ctx context.Context, state *clientState, params *Data) (*Result, error) {
+	if err := state.options.checkEventAge(params); err != nil {
+		return nil, err
+	}
+
+	if c.v2Unsupported.Load() || state.clientV2 == nil {
+		return c.sendV1(ctx, state, params)
 	}
 
-	// Converte os parâmetros para o formato gRPC, incluindo validação e adicionando origin
-	req, err := params.toGRPCRequest(c.options.Origin)
+	result, err := c.sendV2(ctx, state, params)
+	if status.Code(err) != codes.Unimplemented {
+		return result, err
+	}
+
+	// Servidor sem o serviço v2: usa o v1 daqui em diante. A chamada ao v2 serviu apenas para
+	// negociar a versão e não é contada nas tentativas.
+	c.v2Unsupported.Store(true)
+	return c.sendV1(ctx, state, params)
+}
+
+// sendV2 envia uma notificação através do RPC Notify do serviço v2
+func (c *NotifyClient) sendV2(ctx context.Context, state *clientState, params *Data) (*Result, error) {
+	req, err := params.toGRPCRequestV2(state.options.Origin)
 	if err != nil {
-		return fmt.Errorf("parâmetros inválidos: %w", err)
+		return nil, fmt.Errorf("parâmetros inválidos: %w", err)
 	}
 
+	var resp *notificationsv2.NotifyResponse
+	attempts, err := c.callWithRetries(ctx, state, "enviar notificação", state.options.maxRetries(params.severity()), func(ctx context.Context, p *peer.Peer) error {
+		var err error
+		resp, err = state.clientV2.Notify(ctx, req, state.options.callOptions(req, p)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newResult(resp, attempts), nil
+}
+
+// sendV1 envia uma notificação através do RPC Notify do serviço v1
+func (c *NotifyClient) sendV1(ctx context.Context, state *clientState, params *Data) (*Result, error) {
+	req, err := params.toGRPCRequest(state.options.Origin)
+	if err != nil {
+		return nil, fmt.Errorf("parâmetros inválidos: %w", err)
+	}
+
+	var resp *notifications.NotifyResponse
+	attempts, err := c.callWithRetries(ctx, state, "enviar notificação", state.options.maxRetries(params.severity()), func(ctx context.Context, p *peer.Peer) error {
+		var err error
+		resp, err = state.client.Notify(ctx, req, state.options.callOptions(req, p)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newResult(resp, attempts), nil
+}
+
+// Read marca uma notificação como lida
+func (c *NotifyClient) Read(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("o ID da notificação não pode ser vazio")
+	}
+
+	state := c.state.Load()
+	req := &notifications.ReadRequest{Id: id}
+
+	_, err := c.call(ctx, state, "marcar notificação como lida", func(ctx context.Context, p *peer.Peer) error {
+		_, err := state.client.Read(ctx, req, state.options.callOptions(req, p)...)
+		return err
+	})
+	return err
+}
+
+// Ping verifica se o servidor está acessível, aguardando a conexão ficar pronta.
+// Respeita o deadline do contexto ou, na ausência dele, o timeout configurado.
+func (c *NotifyClient) Ping(ctx context.Context) error {
+	state := c.state.Load()
+	if state.conn == nil {
+		// Transporte em processo: não há conexão de rede a verificar
+		return nil
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, state.options.Timeout)
+		defer cancel()
+	}
+
+	state.conn.Connect()
+	for {
+		s := state.conn.GetState()
+		if s == connectivity.Ready {
+			return nil
+		}
+		if !state.conn.WaitForStateChange(ctx, s) {
+			return fmt.Errorf("servidor inacessível (endpoint %s, estado %s): %w", c.ActiveEndpoint(), s, ctx.Err())
+		}
+	}
+}
+
+// call executa uma chamada gRPC com o timeout padrão e as retentativas configuradas
+// e retorna o número de tentativas feitas. action descreve a operação nas mensagens de erro.
+func (c *NotifyClient) call(ctx context.Context, state *clientState, action string, fn func(ctx context.Context, p *peer.Peer) error) (int, error) {
+	return c.callWithRetries(ctx, state, action, state.options.MaxRetries, fn)
+}
+
+// callWithRetries é como call, mas com o número máximo de retentativas informado
+func (c *NotifyClient) callWithRetries(ctx context.Context, state *clientState, action string, maxRetries int, fn func(ctx context.Context, p *peer.Peer) error) (int, error) {
+	options := state.options
+
 	// Adiciona timeout ao contexto se não houver um
 	if _, ok := ctx.Deadline(); !ok {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, c.options.Timeout)
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
 		defer cancel()
 	}
 
-	// Tenta enviar a notificação com retentativas
+	// Tenta executar a chamada com retentativas
 	var lastErr error
-	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
-			time.Sleep(c.options.RetryInterval)
+			time.Sleep(options.RetryInterval)
 		}
 
-		_, err := c.client.Notify(ctx, req)
+		var p peer.Peer
+		err := fn(ctx, &p)
+		if p.Addr != nil {
+			c.setActiveEndpoint(options, p.Addr.String())
+		}
 		if err == nil {
-			return nil
+			return attempt + 1, nil
+		}
+
+		// Um método não implementado pelo servidor não passa a existir em uma nova tentativa.
+		// Também não é enviado ao Sentry: quem chama decide se há alternativa, como o serviço v1
+		// ou chamadas unárias no lugar do lote, ou se retorna o erro.
+		if status.Code(err) == codes.Unimplemented {
+			return attempt + 1, fmt.Errorf("falha ao %s (endpoint %s): %w", action, c.ActiveEndpoint(), err)
 		}
 
 		lastErr = err
 		// Captura erro no Sentry, se configurado
-		sentry.CaptureException(fmt.Errorf("tentativa %d falhou ao enviar notificação: %w", attempt+1, err))
+		if !c.quiet {
+			sentry.CaptureException(fmt.Errorf("tentativa %d falhou ao %s (endpoint %s): %w", attempt+1, action, c.ActiveEndpoint(), err))
+		}
 	}
 
-	return fmt.Errorf("falha ao enviar notificação após %d tentativas: %w", c.options.MaxRetries+1, lastErr)
+	return maxRetries + 1, fmt.Errorf("falha ao %s após %d tentativas (endpoint %s): %w", action, maxRetries+1, c.ActiveEndpoint(), lastErr)
 }
 
-// Close fecha a conexão gRPC
+// ActiveEndpoint retorna o endereço do último endpoint que atendeu uma chamada.
+// Antes da primeira chamada, retorna o endereço preferencial configurado.
+func (c *NotifyClient) ActiveEndpoint() string {
+	if endpoint, ok := c.activeEndpoint.Load().(string); ok {
+		return endpoint
+	}
+	return c.state.Load().options.addresses()[0]
+}
+
+// setActiveEndpoint registra o endpoint que atendeu uma chamada e avisa o EndpointObserver
+// quando ele muda
+func (c *NotifyClient) setActiveEndpoint(options *ClientOptions, endpoint string) {
+	previous, _ := c.activeEndpoint.Swap(endpoint).(string)
+	if previous != endpoint && options.EndpointObserver != nil {
+		options.EndpointObserver(previous, endpoint)
+	}
+}
+
+// Close fecha a conexão gRPC e encerra o monitoramento de arquivos de configuração.
+// As notificações de NotifyAsync ainda na fila têm até Timeout para serem enviadas, e os
+// envios ao servidor shadow em andamento, até Timeout para terminar.
 func (c *NotifyClient) Close() error {
-	if c.conn != nil {
-		return c.conn.Close()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.closed:
+		return nil
+	default:
+		close(c.closed)
 	}
-	return nil
+
+	// Dá às notificações assíncronas pendentes e aos envios shadow a chance de terminarem
+	// antes de fechar a conexão
+	state := c.state.Load()
+	c.closeAsync(state.options.Timeout)
+	c.waitShadows(state.options.Timeout)
+
+	return state.close()
 }
 
-// createConnection estabelece uma conexão gRPC com as opções configuradas
-func createConnection(options *ClientOptions) (*grpc.ClientConn, error) {
+// createConnection estabelece uma conexão gRPC com as opções configuradas. Com PICK_FIRST e mais
+// de um endereço, também retorna o failover por health check, já iniciado.
+func createConnection(options *ClientOptions) (*grpc.ClientConn, *healthFailover, error) {
 	// Define as opções de dial
 	dialOpts := []grpc.DialOption{}
 
@@ -112,21 +408,67 @@ func createConnection(options *ClientOptions) (*grpc.ClientConn, error) {
 	if options.EnableTLS {
 		creds, err := credentials.NewClientTLSFromFile(options.TLSCertPath, "")
 		if err != nil {
-			return nil, fmt.Errorf("falha ao carregar certificados TLS: %w", err)
+			return nil, nil, fmt.Errorf("falha ao carregar certificados TLS: %w", err)
 		}
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
 	} else {
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
+	// As conexões de health check usam as mesmas credenciais e opções do usuário, sem os
+	// interceptors e o CompressionObserver, que valem apenas para as chamadas da aplicação
+	healthDialOpts := slices.Concat(dialOpts, options.DialOptions)
+
+	// Interceptors do usuário, executados a cada tentativa
+	if len(options.UnaryInterceptors) > 0 {
+		dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(options.UnaryInterceptors...))
+	}
+
+	// Tamanhos das requisições antes e depois da compressão
+	if options.CompressionObserver != nil {
+		dialOpts = append(dialOpts, grpc.WithStatsHandler(compressionStats{observer: options.CompressionObserver}))
+	}
+
+	// Com mais de um endereço, usa um resolver manual com a política de balanceamento escolhida
+	addresses := options.addresses()
+	target := addresses[0]
+	var failover *healthFailover
+	if len(addresses) > 1 {
+		r := manual.NewBuilderWithScheme("notify")
+		r.InitialState(resolverState(addresses))
+
+		// O healthCheckConfig só é considerado pelo round_robin; com PICK_FIRST o failover
+		// por health check é feito por healthFailover
+		serviceConfig := fmt.Sprintf(`{"loadBalancingConfig": [{"%s": {}}], "healthCheckConfig": {"serviceName": ""}}`, options.LoadBalancing)
+		dialOpts = append(dialOpts, grpc.WithResolvers(r), grpc.WithDefaultServiceConfig(serviceConfig))
+		target = r.Scheme() + ":///notifications"
+
+		if options.LoadBalancing == PICK_FIRST {
+			var err error
+			if failover, err = newHealthFailover(r, addresses, healthDialOpts); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	// Estabelece a conexão com timeout
 	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, options.ServerAddress, dialOpts...)
+	// Opções do usuário por último, para que prevaleçam sobre as da biblioteca
+	dialOpts = append(dialOpts, options.DialOptions...)
+
+	conn, err := grpc.DialContext(ctx, target, dialOpts...)
 	if err != nil {
-		return nil, err
+		if failover != nil {
+			failover.close()
+		}
+		return nil, nil, err
 	}
 
-	return conn, nil
+	// O resolver só pode receber atualizações depois que a conexão o estiver usando
+	if failover != nil {
+		failover.start()
+	}
+	return conn, failover, nil
 }
@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInProcessSkipsV2(t *testing.T) {
+	captured := captureSentry(t)
+	server := &fakeServer{}
+	c := newTestClient(t, server)
+
+	result, err := c.NotifyWithResult(context.Background(), testData("p1"))
+	if err != nil {
+		t.Fatalf("NotifyWithResult: %v", err)
+	}
+	if result.Attempts != 1 {
+		t.Errorf("Attempts = %d, esperado 1", result.Attempts)
+	}
+	if messages := captured(); len(messages) > 0 {
+		t.Errorf("erros enviados ao Sentry: %v", messages)
+	}
+}
+
+func TestV2NegotiationNotCounted(t *testing.T) {
+	captured := captureSentry(t)
+
+	server := &fakeServer{}
+	c, err := NewClient(WithServerAddress(startServer(t, server)), WithOrigin("test"), WithRetryInterval(0), WithTimeout(3*time.Second))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	// A primeira chamada negocia a versão; a segunda já usa o v1 diretamente
+	for i := range 2 {
+		result, err := c.NotifyWithResult(context.Background(), testData("p1"))
+		if err != nil {
+			t.Fatalf("envio %d: %v", i, err)
+		}
+		if result.Attempts != 1 {
+			t.Errorf("envio %d: Attempts = %d, esperado 1", i, result.Attempts)
+		}
+	}
+	if !c.v2Unsupported.Load() {
+		t.Error("v2 não marcado como não suportado")
+	}
+	if messages := captured(); len(messages) > 0 {
+		t.Errorf("erros enviados ao Sentry: %v", messages)
+	}
+}
+
+func TestOccurredAtStamping(t *testing.T) {
+	var mu sync.Mutex
+	var stamps []time.Time
+	server := &fakeServer{notify: func(ctx context.Context, req *notifications.NotifyRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stamps = append(stamps, req.GetOccurredAt().AsTime())
+		if len(stamps) == 1 {
+			return status.Error(codes.Unavailable, "fora do ar")
+		}
+		return nil
+	}}
+	c := newTestClient(t, server)
+
+	params := testData("p1")
+	before := time.Now()
+	if err := c.Notify(context.Background(), params); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	after := time.Now()
+
+	if !params.OccurredAt.IsZero() {
+		t.Errorf("OccurredAt do chamador alterado para %s", params.OccurredAt)
+	}
+	if len(stamps) != 2 {
+		t.Fatalf("%d tentativas, esperado 2", len(stamps))
+	}
+	// O momento é registrado uma vez na chamada e se mantém nas retentativas
+	if stamps[0].Before(before) || stamps[0].After(after) || !stamps[1].Equal(stamps[0]) {
+		t.Errorf("OccurredAt nas tentativas: %v, esperado o mesmo momento entre %s e %s", stamps, before, after)
+	}
+
+	// Um OccurredAt informado é enviado sem alteração
+	occurredAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	params.OccurredAt = occurredAt
+	if err := c.Notify(context.Background(), params); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got := stamps[len(stamps)-1]; !got.Equal(occurredAt) {
+		t.Errorf("OccurredAt enviado %s, esperado %s", got, occurredAt)
+	}
+}
+
+func TestMaxEventAge(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, WithMaxEventAge(time.Hour))
+
+	tests := []struct {
+		name       string
+		occurredAt time.Time
+		tooOld     bool
+	}{
+		{"sem OccurredAt", time.Time{}, false},
+		{"recente", time.Now().Add(-time.Minute), false},
+		{"antiga", time.Now().Add(-2 * time.Hour), true},
+	}
+	for _, tt := range tests {
+		server.calls.Store(0)
+		params := testData("p1")
+		params.OccurredAt = tt.occurredAt
+
+		err := c.Notify(context.Background(), params)
+		if tt.tooOld {
+			if !errors.Is(err, ErrEventTooOld) {
+				t.Errorf("%s: %v, esperado ErrEventTooOld", tt.name, err)
+			}
+			if calls := server.calls.Load(); calls != 0 {
+				t.Errorf("%s: %d chamadas ao servidor, esperado 0", tt.name, calls)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+	}
+
+	// Em NotifyBatch, a notificação antiga recebe ErrEventTooOld no seu resultado
+	params := testData("p1")
+	params.OccurredAt = time.Now().Add(-2 * time.Hour)
+	results, err := c.NotifyBatch(context.Background(), []*Data{params})
+	if err == nil || !errors.Is(results[0].Err, ErrEventTooOld) {
+		t.Errorf("NotifyBatch: %v, resultado %v, esperado ErrEventTooOld", err, results[0].Err)
+	}
+}
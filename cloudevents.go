@@ -0,0 +1,427 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+)
+
+// Constantes do formato CloudEvents
+const (
+	// Versão da especificação CloudEvents suportada
+	CloudEventsSpecVersion = "1.0"
+
+	// Prefixo do atributo type; o evento de uma notificação CAMPAIGN/FAILED tem o tipo
+	// "com.adseleto.notify.campaign.failed"
+	CloudEventTypePrefix = "com.adseleto.notify."
+
+	// Content-Type do modo estruturado, com o evento inteiro no corpo
+	CloudEventsContentType = "application/cloudevents+json"
+
+	// Extensões com a severidade e a chave de deduplicação da notificação
+	CloudEventSeverityExtension = "severity"
+	CloudEventDedupKeyExtension = "dedupkey"
+)
+
+// Tamanho máximo do corpo lido por ReadCloudEvent
+const maxCloudEventSize = 1024 * 1024
+
+// CloudEvent é um evento CloudEvents 1.0 no formato JSON. Os atributos de contexto são
+// campos; as extensões, com valores string, ficam em Extensions.
+type CloudEvent struct {
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	Data            json.RawMessage
+	Extensions      map[string]string
+}
+
+// cloudEventData é o conteúdo do atributo data de uma notificação
+type cloudEventData struct {
+	Metadata           map[string]string `json:"metadata,omitempty"`
+	StructuredMetadata map[string]any    `json:"structured_metadata,omitempty"`
+}
+
+// ToCloudEvent converte a notificação em um CloudEvent, com a mesma validação das requests gRPC.
+// type vem de Scope e Type, source da origem informada, subject de ProjectID e time de OccurredAt.
+// O id é a DedupKey, quando informada, para que os consumidores dedupliquem pelo par source/id;
+// caso contrário, é gerado aleatoriamente.
+//
+// Como em Validate, os erros de validação (da notificação ou da origem) são mensagens em inglês;
+// apenas a falha ao gerar o id aleatório, que não depende da notificação, é um erro do cliente.
+func (np *Data) ToCloudEvent(origin string) (*CloudEvent, error) {
+	if err := np.Validate(); err != nil {
+		return nil, err
+	}
+	if origin == "" {
+		return nil, fmt.Errorf("CloudEvent source is required: origin is empty")
+	}
+
+	data, err := json.Marshal(cloudEventData{Metadata: np.Metadata, StructuredMetadata: np.StructuredMetadata})
+	if err != nil {
+		return nil, fmt.Errorf("invalid structured metadata: %w", err)
+	}
+
+	id := np.DedupKey
+	if id == "" {
+		random := make([]byte, 16)
+		if _, err := rand.Read(random); err != nil {
+			return nil, fmt.Errorf("falha ao gerar o ID do evento: %w", err)
+		}
+		id = hex.EncodeToString(random)
+	}
+
+	event := &CloudEvent{
+		ID:              id,
+		Source:          origin,
+		Type:            CloudEventTypePrefix + strings.ToLower(np.Scope) + "." + strings.ToLower(np.Type),
+		Subject:         np.ProjectID,
+		DataContentType: "application/json",
+		Data:            data,
+		Extensions:      map[string]string{CloudEventSeverityExtension: np.severity()},
+	}
+	if !np.OccurredAt.IsZero() {
+		event.Time = np.OccurredAt.UTC()
+	}
+	if np.DedupKey != "" {
+		event.Extensions[CloudEventDedupKeyExtension] = np.DedupKey
+	}
+	return event, nil
+}
+
+// CloudEventFromRequest converte uma request gRPC do serviço v1 em um CloudEvent
+func CloudEventFromRequest(req *notifications.NotifyRequest) (*CloudEvent, error) {
+	params := &Data{
+		ProjectID: req.GetProjectId(),
+		Scope:     req.GetScope(),
+		Type:      req.GetType(),
+		Metadata:  req.GetMetadata(),
+		Severity:  req.GetSeverity(),
+	}
+	if req.GetOccurredAt() != nil {
+		params.OccurredAt = req.GetOccurredAt().AsTime()
+	}
+	return params.ToCloudEvent(req.GetOrigin())
+}
+
+// ToData converte o evento de volta em uma notificação e a valida. O evento deve ter sido
+// gerado por ToCloudEvent ou seguir o mesmo formato. Números em StructuredMetadata são
+// decodificados como float64, como no serviço v2.
+func (e *CloudEvent) ToData() (*Data, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+
+	scope, typ, ok := strings.Cut(strings.TrimPrefix(e.Type, CloudEventTypePrefix), ".")
+	if !strings.HasPrefix(e.Type, CloudEventTypePrefix) || !ok {
+		return nil, fmt.Errorf("invalid CloudEvent type: %s. Use %s<scope>.<type>", e.Type, CloudEventTypePrefix)
+	}
+
+	var data cloudEventData
+	if len(e.Data) > 0 {
+		if mediaType, _, err := mime.ParseMediaType(e.DataContentType); e.DataContentType != "" && (err != nil || mediaType != "application/json") {
+			return nil, fmt.Errorf("invalid CloudEvent datacontenttype: %s. Use application/json", e.DataContentType)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(e.Data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&data); err != nil {
+			return nil, fmt.Errorf("invalid CloudEvent data: %w", err)
+		}
+	}
+
+	params := &Data{
+		ProjectID:          e.Subject,
+		Scope:              strings.ToUpper(scope),
+		Type:               strings.ToUpper(typ),
+		Metadata:           data.Metadata,
+		StructuredMetadata: data.StructuredMetadata,
+		DedupKey:           e.Extensions[CloudEventDedupKeyExtension],
+		Severity:           e.Extensions[CloudEventSeverityExtension],
+		OccurredAt:         e.Time,
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// ToRequest converte o evento em uma request gRPC do serviço v1, com source como origem
+func (e *CloudEvent) ToRequest() (*notifications.NotifyRequest, error) {
+	params, err := e.ToData()
+	if err != nil {
+		return nil, err
+	}
+	return params.toGRPCRequest(e.Source)
+}
+
+// validate verifica os atributos obrigatórios e os nomes das extensões
+func (e *CloudEvent) validate() error {
+	var errs []error
+	if e.ID == "" {
+		errs = append(errs, fmt.Errorf("CloudEvent id is required"))
+	}
+	if e.Source == "" {
+		errs = append(errs, fmt.Errorf("CloudEvent source is required"))
+	}
+	if e.Type == "" {
+		errs = append(errs, fmt.Errorf("CloudEvent type is required"))
+	}
+	for name := range e.Extensions {
+		if !validCloudEventAttribute(name) || slices.Contains(cloudEventAttributes, name) {
+			errs = append(errs, fmt.Errorf("invalid CloudEvent extension name: %s", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Atributos de contexto definidos pela especificação, que não podem ser usados como extensões
+var cloudEventAttributes = []string{"specversion", "id", "source", "type", "subject", "time", "datacontenttype", "dataschema", "data", "data_base64"}
+
+// validCloudEventAttribute indica se o nome usa apenas letras minúsculas e dígitos, como exige a especificação
+func validCloudEventAttribute(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON codifica o evento no formato JSON do CloudEvents, com as extensões
+// no mesmo nível dos atributos de contexto
+func (e CloudEvent) MarshalJSON() ([]byte, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]any, len(e.Extensions)+8)
+	for name, value := range e.Extensions {
+		fields[name] = value
+	}
+	fields["specversion"] = CloudEventsSpecVersion
+	fields["id"] = e.ID
+	fields["source"] = e.Source
+	fields["type"] = e.Type
+	if e.Subject != "" {
+		fields["subject"] = e.Subject
+	}
+	if !e.Time.IsZero() {
+		fields["time"] = e.Time.Format(time.RFC3339Nano)
+	}
+	if e.DataContentType != "" {
+		fields["datacontenttype"] = e.DataContentType
+	}
+	if len(e.Data) > 0 {
+		fields["data"] = e.Data
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodifica um evento no formato JSON do CloudEvents. Apenas a versão 1.0 e
+// dados JSON (sem data_base64) são aceitos; extensões que não são strings são mantidas
+// como o texto JSON do valor.
+func (e *CloudEvent) UnmarshalJSON(content []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return err
+	}
+
+	*e = CloudEvent{}
+	var specVersion, eventTime string
+	attributes := map[string]*string{
+		"specversion":     &specVersion,
+		"id":              &e.ID,
+		"source":          &e.Source,
+		"type":            &e.Type,
+		"subject":         &e.Subject,
+		"time":            &eventTime,
+		"datacontenttype": &e.DataContentType,
+	}
+
+	for name, value := range fields {
+		if target, ok := attributes[name]; ok {
+			if err := json.Unmarshal(value, target); err != nil {
+				return fmt.Errorf("invalid CloudEvent %s: %w", name, err)
+			}
+			continue
+		}
+
+		switch name {
+		case "data":
+			e.Data = slices.Clone(value)
+		case "data_base64":
+			return fmt.Errorf("CloudEvent data_base64 is not supported, use JSON data")
+		case "dataschema":
+			// Não é usado pelas notificações
+		default:
+			if e.Extensions == nil {
+				e.Extensions = make(map[string]string)
+			}
+			var str string
+			if err := json.Unmarshal(value, &str); err != nil {
+				str = string(value)
+			}
+			e.Extensions[name] = str
+		}
+	}
+
+	if specVersion != CloudEventsSpecVersion {
+		return fmt.Errorf("unsupported CloudEvent specversion: %q. Use %s", specVersion, CloudEventsSpecVersion)
+	}
+	if eventTime != "" {
+		t, err := time.Parse(time.RFC3339Nano, eventTime)
+		if err != nil {
+			return fmt.Errorf("invalid CloudEvent time: %w", err)
+		}
+		e.Time = t
+	}
+	return e.validate()
+}
+
+// EncodeStructured codifica o evento para uma requisição HTTP no modo estruturado:
+// o evento inteiro no corpo, com Content-Type application/cloudevents+json
+func (e *CloudEvent) EncodeStructured() (http.Header, []byte, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", CloudEventsContentType+"; charset=utf-8")
+	return header, body, nil
+}
+
+// EncodeBinary codifica o evento para uma requisição HTTP no modo binário: os atributos
+// e as extensões em cabeçalhos ce-*, e apenas data no corpo, com Content-Type datacontenttype
+func (e *CloudEvent) EncodeBinary() (http.Header, []byte, error) {
+	if err := e.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	header := make(http.Header)
+	header.Set("ce-specversion", CloudEventsSpecVersion)
+	header.Set("ce-id", encodeCloudEventHeader(e.ID))
+	header.Set("ce-source", encodeCloudEventHeader(e.Source))
+	header.Set("ce-type", encodeCloudEventHeader(e.Type))
+	if e.Subject != "" {
+		header.Set("ce-subject", encodeCloudEventHeader(e.Subject))
+	}
+	if !e.Time.IsZero() {
+		header.Set("ce-time", e.Time.Format(time.RFC3339Nano))
+	}
+	for _, name := range slices.Sorted(maps.Keys(e.Extensions)) {
+		header.Set("ce-"+name, encodeCloudEventHeader(e.Extensions[name]))
+	}
+	if e.DataContentType != "" {
+		header.Set("Content-Type", e.DataContentType)
+	}
+	return header, slices.Clone(e.Data), nil
+}
+
+// DecodeCloudEvent decodifica um evento recebido por HTTP em qualquer um dos modos:
+// estruturado, quando o Content-Type é application/cloudevents+json, ou binário, quando há
+// o cabeçalho ce-specversion
+func DecodeCloudEvent(header http.Header, body []byte) (*CloudEvent, error) {
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType == CloudEventsContentType {
+		event := &CloudEvent{}
+		if err := json.Unmarshal(body, event); err != nil {
+			return nil, fmt.Errorf("invalid structured CloudEvent: %w", err)
+		}
+		return event, nil
+	}
+
+	if header.Get("ce-specversion") == "" {
+		return nil, fmt.Errorf("request is not a CloudEvent: missing ce-specversion header or %s content type", CloudEventsContentType)
+	}
+	if specVersion := header.Get("ce-specversion"); specVersion != CloudEventsSpecVersion {
+		return nil, fmt.Errorf("unsupported CloudEvent specversion: %q. Use %s", specVersion, CloudEventsSpecVersion)
+	}
+
+	event := &CloudEvent{
+		DataContentType: header.Get("Content-Type"),
+		Data:            slices.Clone(body),
+	}
+	for name, values := range header {
+		name = strings.ToLower(name)
+		if !strings.HasPrefix(name, "ce-") || len(values) == 0 {
+			continue
+		}
+		value, err := url.PathUnescape(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid CloudEvent header %s: %w", name, err)
+		}
+
+		switch attribute := strings.TrimPrefix(name, "ce-"); attribute {
+		case "specversion", "dataschema":
+		case "id":
+			event.ID = value
+		case "source":
+			event.Source = value
+		case "type":
+			event.Type = value
+		case "subject":
+			event.Subject = value
+		case "time":
+			if event.Time, err = time.Parse(time.RFC3339Nano, value); err != nil {
+				return nil, fmt.Errorf("invalid CloudEvent time: %w", err)
+			}
+		default:
+			if event.Extensions == nil {
+				event.Extensions = make(map[string]string)
+			}
+			event.Extensions[attribute] = value
+		}
+	}
+
+	if err := event.validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ReadCloudEvent lê e decodifica o evento de uma requisição HTTP, em qualquer um dos modos
+func ReadCloudEvent(r *http.Request) (*CloudEvent, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCloudEventSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler o evento: %w", err)
+	}
+	if len(body) > maxCloudEventSize {
+		return nil, fmt.Errorf("evento maior que o limite de %d bytes", maxCloudEventSize)
+	}
+	return DecodeCloudEvent(r.Header, body)
+}
+
+// encodeCloudEventHeader codifica um valor para um cabeçalho ce-*, com percent-encoding dos
+// caracteres fora do ASCII imprimível, de espaços, aspas e do próprio '%'
+func encodeCloudEventHeader(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c <= ' ' || c > '~' || c == '"' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,201 @@
+package notify
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+)
+
+// testCloudEventData retorna uma notificação com todos os campos levados ao CloudEvent
+func testCloudEventData() *Data {
+	params := testData("p1")
+	params.Metadata = map[string]string{"campaign": "c1", "nome": "promoção de verão"}
+	params.StructuredMetadata = map[string]any{"total": 3.5, "ativo": true, "tags": []any{"a", "b"}}
+	params.DedupKey = "dedup-1"
+	params.Severity = CRITICAL
+	params.OccurredAt = time.Date(2024, 5, 1, 12, 30, 0, 123000000, time.UTC)
+	return params
+}
+
+func TestCloudEventRoundTrip(t *testing.T) {
+	encodings := []struct {
+		name   string
+		encode func(*CloudEvent) (http.Header, []byte, error)
+	}{
+		{"estruturado", (*CloudEvent).EncodeStructured},
+		{"binário", (*CloudEvent).EncodeBinary},
+	}
+	for _, tt := range encodings {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testCloudEventData()
+			event, err := params.ToCloudEvent("origem/teste")
+			if err != nil {
+				t.Fatalf("ToCloudEvent: %v", err)
+			}
+			if event.ID != "dedup-1" || event.Type != "com.adseleto.notify.system.bounce" || event.Subject != "p1" {
+				t.Errorf("atributos inesperados: %+v", event)
+			}
+
+			header, body, err := tt.encode(event)
+			if err != nil {
+				t.Fatalf("codificação: %v", err)
+			}
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			r.Header = header
+
+			decoded, err := ReadCloudEvent(r)
+			if err != nil {
+				t.Fatalf("ReadCloudEvent: %v", err)
+			}
+			if decoded.Source != "origem/teste" || !decoded.Time.Equal(params.OccurredAt) {
+				t.Errorf("source %q, time %s após a decodificação", decoded.Source, decoded.Time)
+			}
+
+			got, err := decoded.ToData()
+			if err != nil {
+				t.Fatalf("ToData: %v", err)
+			}
+			if !reflect.DeepEqual(got, params) {
+				t.Errorf("ToData = %+v, esperado %+v", got, params)
+			}
+		})
+	}
+}
+
+func TestCloudEventRandomID(t *testing.T) {
+	first, err := testData("p1").ToCloudEvent("test")
+	if err != nil {
+		t.Fatalf("ToCloudEvent: %v", err)
+	}
+	second, err := testData("p1").ToCloudEvent("test")
+	if err != nil {
+		t.Fatalf("ToCloudEvent: %v", err)
+	}
+	if first.ID == "" || first.ID == second.ID {
+		t.Errorf("IDs %q e %q, esperados IDs aleatórios distintos", first.ID, second.ID)
+	}
+	if _, ok := first.Extensions[CloudEventDedupKeyExtension]; ok {
+		t.Error("extensão dedupkey presente sem DedupKey")
+	}
+}
+
+func TestCloudEventFromRequest(t *testing.T) {
+	occurredAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	req := &notifications.NotifyRequest{
+		ProjectId:  "p1",
+		Scope:      SYSTEM,
+		Type:       BOUNCE,
+		Origin:     "test",
+		Metadata:   map[string]string{"campaign": "c1"},
+		Severity:   WARNING,
+		OccurredAt: timestamppb.New(occurredAt),
+	}
+
+	event, err := CloudEventFromRequest(req)
+	if err != nil {
+		t.Fatalf("CloudEventFromRequest: %v", err)
+	}
+	got, err := event.ToRequest()
+	if err != nil {
+		t.Fatalf("ToRequest: %v", err)
+	}
+	if got.ProjectId != "p1" || got.Scope != SYSTEM || got.Type != BOUNCE || got.Origin != "test" || got.Severity != WARNING {
+		t.Errorf("request inesperada: %+v", got)
+	}
+	if got.Metadata["campaign"] != "c1" || !got.OccurredAt.AsTime().Equal(occurredAt) {
+		t.Errorf("metadata %v, occurred_at %v", got.Metadata, got.OccurredAt.AsTime())
+	}
+}
+
+func TestCloudEventBinaryHeaderEncoding(t *testing.T) {
+	event := &CloudEvent{
+		ID:         "id com espaço",
+		Source:     "test",
+		Type:       CloudEventTypePrefix + "system.bounce",
+		Subject:    "projeto \"ç\" 100%",
+		Extensions: map[string]string{CloudEventSeverityExtension: INFO},
+	}
+	header, body, err := event.EncodeBinary()
+	if err != nil {
+		t.Fatalf("EncodeBinary: %v", err)
+	}
+	if got, want := header.Get("ce-subject"), "projeto%20%22%C3%A7%22%20100%25"; got != want {
+		t.Errorf("ce-subject = %q, esperado %q", got, want)
+	}
+
+	decoded, err := DecodeCloudEvent(header, body)
+	if err != nil {
+		t.Fatalf("DecodeCloudEvent: %v", err)
+	}
+	if decoded.ID != event.ID || decoded.Subject != event.Subject {
+		t.Errorf("id %q, subject %q após a decodificação", decoded.ID, decoded.Subject)
+	}
+}
+
+func TestCloudEventInvalid(t *testing.T) {
+	structured := http.Header{"Content-Type": {CloudEventsContentType}}
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+	}{
+		{"sem CloudEvent", http.Header{"Content-Type": {"application/json"}}, `{}`},
+		{"specversion binário", http.Header{"Ce-Specversion": {"0.3"}, "Ce-Id": {"1"}, "Ce-Source": {"s"}, "Ce-Type": {"t"}}, ``},
+		{"sem id binário", http.Header{"Ce-Specversion": {"1.0"}, "Ce-Source": {"s"}, "Ce-Type": {"t"}}, ``},
+		{"specversion", structured, `{"specversion":"0.3","id":"1","source":"s","type":"t"}`},
+		{"sem source", structured, `{"specversion":"1.0","id":"1","type":"t"}`},
+		{"data_base64", structured, `{"specversion":"1.0","id":"1","source":"s","type":"t","data_base64":"e30="}`},
+		{"extensão inválida", structured, `{"specversion":"1.0","id":"1","source":"s","type":"t","Severity":"INFO"}`},
+		{"time inválido", structured, `{"specversion":"1.0","id":"1","source":"s","type":"t","time":"ontem"}`},
+	}
+	for _, tt := range tests {
+		if _, err := DecodeCloudEvent(tt.header, []byte(tt.body)); err == nil {
+			t.Errorf("%s: evento aceito", tt.name)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(make([]byte, maxCloudEventSize+1)))
+	r.Header = structured
+	if _, err := ReadCloudEvent(r); err == nil {
+		t.Error("evento acima do limite aceito")
+	}
+}
+
+func TestCloudEventToDataInvalid(t *testing.T) {
+	valid := func() *CloudEvent {
+		return &CloudEvent{ID: "1", Source: "test", Type: CloudEventTypePrefix + "system.bounce", Subject: "p1"}
+	}
+	tests := []struct {
+		name   string
+		modify func(*CloudEvent)
+	}{
+		{"prefixo do type", func(e *CloudEvent) { e.Type = "com.outro.system.bounce" }},
+		{"type sem tipo", func(e *CloudEvent) { e.Type = CloudEventTypePrefix + "system" }},
+		{"escopo inválido", func(e *CloudEvent) { e.Type = CloudEventTypePrefix + "outro.bounce" }},
+		{"datacontenttype", func(e *CloudEvent) { e.DataContentType = "text/plain"; e.Data = []byte(`{}`) }},
+		{"campo desconhecido em data", func(e *CloudEvent) { e.Data = []byte(`{"outro":1}`) }},
+		{"severidade", func(e *CloudEvent) { e.Extensions = map[string]string{CloudEventSeverityExtension: "URGENTE"} }},
+	}
+	if _, err := valid().ToData(); err != nil {
+		t.Fatalf("evento válido rejeitado: %v", err)
+	}
+	for _, tt := range tests {
+		event := valid()
+		tt.modify(event)
+		if _, err := event.ToData(); err == nil {
+			t.Errorf("%s: evento aceito", tt.name)
+		}
+	}
+
+	if _, err := testData("p1").ToCloudEvent(""); err == nil || !strings.Contains(err.Error(), "CloudEvent source is required") {
+		t.Errorf("ToCloudEvent sem origem: %v, esperado erro de validação do source", err)
+	}
+}
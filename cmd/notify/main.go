@@ -0,0 +1,276 @@
+// Command notify envia notificações e consulta o serviço de notificações a partir da linha de comando.
+//
+// Uso:
+//
+//	notify send --project X --scope CAMPAIGN --type FAILED --severity WARNING --meta chave=valor
+//	notify read --id ID
+//	notify replay --file notificacoes.jsonl --concurrency 4 --rate 10
+//	notify ping
+//	notify scopes
+//	notify types
+//
+// A conexão é configurada pelas variáveis de ambiente NOTIFY_*, por um arquivo (--config)
+// e pelas flags de conexão, nessa ordem de prioridade crescente: o arquivo sobrescreve apenas
+// as variáveis cujos campos define, e as flags, apenas quando informadas. Sem origem em
+// nenhuma dessas fontes, é usada "notify-cli".
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AdSeleto/notify"
+)
+
+const usage = `Uso: notify <comando> [flags]
+
+Comandos:
+  send     Envia uma notificação
+  read     Marca uma notificação como lida
+  replay   Envia as notificações de um arquivo JSON Lines
+  ping     Verifica se o servidor está acessível
+  scopes   Lista os escopos permitidos
+  types    Lista os tipos de notificação permitidos
+
+Use "notify <comando> -h" para ver as flags de cada comando.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "send":
+		err = runSend(args)
+	case "read":
+		err = runRead(args)
+	case "replay":
+		err = runReplay(args)
+	case "ping":
+		err = runPing(args)
+	case "scopes":
+		fmt.Println(strings.Join(notify.Scopes(), "\n"))
+	case "types":
+		fmt.Println(strings.Join(notify.Types(), "\n"))
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "comando desconhecido: %s\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "erro: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runSend envia uma notificação
+func runSend(args []string) error {
+	fs := flag.NewFlagSet("send", flag.ExitOnError)
+	conn := addConnectionFlags(fs)
+	project := fs.String("project", "", "ID do projeto")
+	scope := fs.String("scope", "", "escopo da notificação ("+strings.Join(notify.Scopes(), ", ")+")")
+	typ := fs.String("type", "", "tipo da notificação ("+strings.Join(notify.Types(), ", ")+")")
+	severity := fs.String("severity", "", "severidade ("+strings.Join(notify.Severities(), ", ")+"); vazio usa a padrão do tipo")
+	occurredAt := fs.String("occurred-at", "", "momento do evento em RFC 3339 (ex.: 2025-01-02T15:04:05Z); vazio usa o momento do envio")
+	dryRun := fs.Bool("dry-run", false, "valida a notificação e a exibe sem enviá-la")
+	meta := metadataFlag{}
+	fs.Var(meta, "meta", "metadado no formato chave=valor (pode ser repetido)")
+	fs.Parse(args)
+
+	var occurred time.Time
+	if *occurredAt != "" {
+		var err error
+		if occurred, err = time.Parse(time.RFC3339, *occurredAt); err != nil {
+			return fmt.Errorf("--occurred-at inválido: %w", err)
+		}
+	}
+
+	var opts []notify.Option
+	if *dryRun {
+		opts = append(opts, notify.WithDryRun(), notify.WithDryRunRecorder(notify.JSONLRecorder(os.Stdout)))
+	}
+	c, err := conn.client(opts...)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	data := &notify.Data{
+		ProjectID:  *project,
+		Scope:      strings.ToUpper(*scope),
+		Type:       strings.ToUpper(*typ),
+		Metadata:   meta,
+		Severity:   strings.ToUpper(*severity),
+		OccurredAt: occurred,
+	}
+	result, err := c.NotifyWithResult(context.Background(), data)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Println("dry run: notificação válida, não enviada")
+		return nil
+	}
+	fmt.Printf("notificação enviada: id=%s tentativas=%d\n", result.ID, result.Attempts)
+	return nil
+}
+
+// runRead marca uma notificação como lida
+func runRead(args []string) error {
+	fs := flag.NewFlagSet("read", flag.ExitOnError)
+	conn := addConnectionFlags(fs)
+	id := fs.String("id", "", "ID da notificação")
+	fs.Parse(args)
+
+	c, err := conn.client()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err := c.Read(context.Background(), *id); err != nil {
+		return err
+	}
+
+	fmt.Println("notificação marcada como lida")
+	return nil
+}
+
+// runPing verifica se o servidor está acessível
+func runPing(args []string) error {
+	fs := flag.NewFlagSet("ping", flag.ExitOnError)
+	conn := addConnectionFlags(fs)
+	fs.Parse(args)
+
+	c, err := conn.client()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	start := time.Now()
+	if err := c.Ping(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s respondeu em %s\n", c.ActiveEndpoint(), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// connectionFlags agrupa as flags de conexão comuns a todos os comandos
+type connectionFlags struct {
+	fs         *flag.FlagSet
+	config     *string
+	server     *string
+	origin     *string
+	timeout    *time.Duration
+	maxRetries *int
+	tlsCert    *string
+}
+
+// addConnectionFlags registra as flags de conexão no FlagSet
+func addConnectionFlags(fs *flag.FlagSet) *connectionFlags {
+	return &connectionFlags{
+		fs:         fs,
+		config:     fs.String("config", "", "arquivo de configuração JSON ou YAML"),
+		server:     fs.String("server", "", "endereço do servidor gRPC; vários separados por vírgula (env "+notify.EnvServerAddress+")"),
+		origin:     fs.String("origin", "notify-cli", "origem da notificação (env "+notify.EnvOrigin+")"),
+		timeout:    fs.Duration("timeout", 0, "timeout de cada requisição (env "+notify.EnvTimeout+")"),
+		maxRetries: fs.Int("max-retries", 0, "número máximo de tentativas (env "+notify.EnvMaxRetries+")"),
+		tlsCert:    fs.String("tls-cert", "", "certificado TLS (env "+notify.EnvTLSCert+")"),
+	}
+}
+
+// client cria o cliente a partir do ambiente, do arquivo de configuração e das flags informadas,
+// nessa ordem de prioridade crescente. As opções extras são aplicadas por último.
+func (f *connectionFlags) client(extra ...notify.Option) (*notify.NotifyClient, error) {
+	// A origem padrão da CLI vem primeiro, para ser usada apenas se nenhuma outra fonte a definir
+	opts := []notify.Option{notify.WithOrigin(*f.origin)}
+
+	env, err := notify.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	envOpts, err := env.Options()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, envOpts...)
+
+	// O arquivo prevalece sobre o ambiente apenas nos campos que define
+	if *f.config != "" {
+		file, err := notify.LoadConfigFile(*f.config)
+		if err != nil {
+			return nil, err
+		}
+		fileOpts, err := file.Options()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, fileOpts...)
+	}
+
+	// Apenas as flags informadas explicitamente sobrescrevem o ambiente e o arquivo
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "server":
+			if addresses := serverAddresses(*f.server); len(addresses) > 1 {
+				opts = append(opts, notify.WithServerAddresses(addresses...))
+			} else {
+				opts = append(opts, notify.WithServerAddress(strings.Join(addresses, "")))
+			}
+		case "origin":
+			opts = append(opts, notify.WithOrigin(*f.origin))
+		case "timeout":
+			opts = append(opts, notify.WithTimeout(*f.timeout))
+		case "max-retries":
+			opts = append(opts, notify.WithMaxRetries(*f.maxRetries))
+		case "tls-cert":
+			opts = append(opts, notify.WithTLS(*f.tlsCert))
+		}
+	})
+	opts = append(opts, extra...)
+
+	return notify.NewClient(opts...)
+}
+
+// serverAddresses separa os endereços de --server, ignorando espaços e itens vazios
+func serverAddresses(value string) []string {
+	var addresses []string
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// metadataFlag acumula flags --meta chave=valor
+type metadataFlag map[string]string
+
+func (m metadataFlag) String() string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m metadataFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("metadado inválido %q: use o formato chave=valor", value)
+	}
+	m[k] = v
+	return nil
+}
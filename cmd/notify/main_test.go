@@ -0,0 +1,24 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestServerAddresses(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{"notify:50051", []string{"notify:50051"}},
+		{" notify:50051 , ", []string{"notify:50051"}},
+		{"a:1, b:2", []string{"a:1", "b:2"}},
+		{"a:1,,b:2,", []string{"a:1", "b:2"}},
+	}
+	for _, tt := range tests {
+		if got := serverAddresses(tt.value); !slices.Equal(got, tt.want) {
+			t.Errorf("serverAddresses(%q) = %q, esperado %q", tt.value, got, tt.want)
+		}
+	}
+}
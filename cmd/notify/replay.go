@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/AdSeleto/notify"
+)
+
+// Status de cada linha no arquivo de resultados
+const (
+	statusOK      = "ok"
+	statusFailed  = "failed"
+	statusInvalid = "invalid"
+)
+
+// replayResult é uma linha do arquivo de resultados
+type replayResult struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// runReplay envia as notificações de um arquivo JSON Lines
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	conn := addConnectionFlags(fs)
+	file := fs.String("file", "", "arquivo JSON Lines com uma notificação por linha")
+	resultsPath := fs.String("results", "", "arquivo de resultados (padrão: <file>.results.jsonl)")
+	resume := fs.Bool("resume", false, "pula as linhas já enviadas com sucesso segundo o arquivo de resultados")
+	concurrency := fs.Int("concurrency", 4, "número de envios simultâneos")
+	rate := fs.Float64("rate", 0, "máximo de envios por segundo (0 = sem limite)")
+	fs.Parse(args)
+
+	if *file == "" {
+		return fmt.Errorf("informe o arquivo com --file")
+	}
+	if *concurrency < 1 {
+		return fmt.Errorf("--concurrency deve ser maior que zero")
+	}
+	if *resultsPath == "" {
+		*resultsPath = *file + ".results.jsonl"
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	// Linhas já enviadas em uma execução anterior
+	done := map[int]bool{}
+	if *resume {
+		if done, err = readSentLines(*resultsPath); err != nil {
+			return err
+		}
+	}
+
+	c, err := conn.client()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	// Sem --resume o arquivo de resultados é recriado
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if *resume {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	output, err := os.OpenFile(*resultsPath, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	results := &resultsWriter{encoder: json.NewEncoder(output), counts: map[string]int{}}
+
+	// Limita a taxa de envio, se configurado
+	var ticker *time.Ticker
+	if *rate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / *rate))
+		defer ticker.Stop()
+	}
+
+	lines := make(chan *notify.Line)
+	var wg sync.WaitGroup
+	for range *concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for line := range lines {
+				if err := c.Notify(context.Background(), line.Data); err != nil {
+					results.write(line.Number, statusFailed, err)
+				} else {
+					results.write(line.Number, statusOK, nil)
+				}
+			}
+		}()
+	}
+
+	reader := notify.NewJSONLReader(input)
+	skipped := 0
+	var readErr error
+	for {
+		line, err := reader.Next()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				readErr = err
+			}
+			break
+		}
+		if done[line.Number] {
+			skipped++
+			continue
+		}
+		if line.Err != nil {
+			results.write(line.Number, statusInvalid, line.Err)
+			continue
+		}
+		if ticker != nil {
+			<-ticker.C
+		}
+		lines <- line
+	}
+	close(lines)
+	wg.Wait()
+
+	fmt.Printf("%d enviadas, %d falharam, %d inválidas, %d já enviadas anteriormente. Resultados em %s\n",
+		results.counts[statusOK], results.counts[statusFailed], results.counts[statusInvalid], skipped, *resultsPath)
+
+	if readErr != nil {
+		return readErr
+	}
+	if results.counts[statusFailed] > 0 || results.counts[statusInvalid] > 0 {
+		return fmt.Errorf("%d linhas não foram enviadas; use --resume para tentar novamente apenas essas linhas", results.counts[statusFailed]+results.counts[statusInvalid])
+	}
+	return nil
+}
+
+// resultsWriter grava os resultados de forma segura entre goroutines
+type resultsWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	counts  map[string]int
+}
+
+// write grava o resultado de uma linha e imprime os erros no stderr
+func (w *resultsWriter) write(line int, status string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	result := replayResult{Line: line, Status: status}
+	if err != nil {
+		result.Error = err.Error()
+		fmt.Fprintf(os.Stderr, "linha %d: %s: %v\n", line, status, err)
+	}
+	w.counts[status]++
+	if err := w.encoder.Encode(result); err != nil {
+		fmt.Fprintf(os.Stderr, "falha ao gravar resultado da linha %d: %v\n", line, err)
+	}
+}
+
+// readSentLines retorna as linhas registradas como enviadas com sucesso no arquivo de resultados
+func readSentLines(path string) (map[int]bool, error) {
+	done := map[int]bool{}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var result replayResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			return nil, fmt.Errorf("arquivo de resultados inválido %s: %w", path, err)
+		}
+		if result.Status == statusOK {
+			done[result.Line] = true
+		}
+	}
+	return done, scanner.Err()
+}
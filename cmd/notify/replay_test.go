@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replayServer registra os projetos recebidos e recusa os listados em reject
+type replayServer struct {
+	notifications.UnimplementedNotificationsServiceServer
+
+	mu       sync.Mutex
+	projects []string
+	reject   map[string]bool
+}
+
+func (s *replayServer) Notify(ctx context.Context, req *notifications.NotifyRequest) (*notifications.NotifyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.reject[req.GetProjectId()] {
+		return nil, status.Error(codes.InvalidArgument, "projeto recusado")
+	}
+	s.projects = append(s.projects, req.GetProjectId())
+	return &notifications.NotifyResponse{Id: "id-" + req.GetProjectId()}, nil
+}
+
+// received retorna os projetos recebidos, em ordem alfabética, e limpa o registro
+func (s *replayServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projects := slices.Sorted(slices.Values(s.projects))
+	s.projects = nil
+	return projects
+}
+
+// startReplayServer inicia o servidor de teste e retorna o endereço
+func startReplayServer(t *testing.T, server *replayServer) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	notifications.RegisterNotificationsServiceServer(s, server)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String()
+}
+
+// writeLines grava as linhas em um arquivo temporário e retorna o caminho
+func writeLines(t *testing.T, dir, name string, lines ...string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readResults retorna o status registrado para cada linha no arquivo de resultados
+func readResults(t *testing.T, path string) map[int][]string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	results := map[int][]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var result replayResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatalf("resultado inválido %q: %v", scanner.Text(), err)
+		}
+		results[result.Line] = append(results[result.Line], result.Status)
+	}
+	return results
+}
+
+// notification retorna uma linha JSON Lines com uma notificação válida do projeto
+func notification(projectID string) string {
+	return `{"project_id": "` + projectID + `", "scope": "SYSTEM", "type": "BOUNCE"}`
+}
+
+// replayArgs retorna os argumentos de replay para o servidor e o arquivo informados
+func replayArgs(address, file string, extra ...string) []string {
+	return append([]string{"--server", address, "--origin", "test", "--max-retries", "0", "--file", file}, extra...)
+}
+
+func TestReplayMalformedLines(t *testing.T) {
+	server := &replayServer{}
+	address := startReplayServer(t, server)
+	dir := t.TempDir()
+	file := writeLines(t, dir, "notificacoes.jsonl",
+		notification("p1"),
+		`{"project_id": "p2", `,
+		`{"project_id": "p3", "scope": "SYSTEM", "type": "BOUNCE", "Campo": 1}`,
+		`{"project_id": "p4", "scope": "OUTRO", "type": "BOUNCE"}`,
+		``,
+		notification("p6"),
+	)
+
+	err := runReplay(replayArgs(address, file))
+	if err == nil || !strings.Contains(err.Error(), "3 linhas não foram enviadas") {
+		t.Errorf("runReplay: %v, esperado 3 linhas não enviadas", err)
+	}
+	if got := server.received(); !slices.Equal(got, []string{"p1", "p6"}) {
+		t.Errorf("servidor recebeu %v, esperado [p1 p6]", got)
+	}
+
+	want := map[int][]string{1: {statusOK}, 2: {statusInvalid}, 3: {statusInvalid}, 4: {statusInvalid}, 6: {statusOK}}
+	results := readResults(t, file+".results.jsonl")
+	if len(results) != len(want) {
+		t.Errorf("resultados %v, esperado %v", results, want)
+	}
+	for line, statuses := range want {
+		if !slices.Equal(results[line], statuses) {
+			t.Errorf("linha %d: %v, esperado %v", line, results[line], statuses)
+		}
+	}
+}
+
+func TestReplayResume(t *testing.T) {
+	server := &replayServer{reject: map[string]bool{"p2": true}}
+	address := startReplayServer(t, server)
+	dir := t.TempDir()
+	file := writeLines(t, dir, "notificacoes.jsonl", notification("p1"), notification("p2"), notification("p3"))
+	resultsPath := filepath.Join(dir, "resultados.jsonl")
+
+	// A primeira execução falha na linha 2
+	if err := runReplay(replayArgs(address, file, "--results", resultsPath)); err == nil {
+		t.Fatal("esperado erro para a linha recusada")
+	}
+	if got := server.received(); !slices.Equal(got, []string{"p1", "p3"}) {
+		t.Errorf("primeira execução: servidor recebeu %v, esperado [p1 p3]", got)
+	}
+
+	// Com --resume, apenas a linha que falhou é enviada de novo, e o resultado é acrescentado
+	server.mu.Lock()
+	server.reject = nil
+	server.mu.Unlock()
+	if err := runReplay(replayArgs(address, file, "--results", resultsPath, "--resume")); err != nil {
+		t.Fatalf("runReplay com --resume: %v", err)
+	}
+	if got := server.received(); !slices.Equal(got, []string{"p2"}) {
+		t.Errorf("execução retomada: servidor recebeu %v, esperado [p2]", got)
+	}
+
+	results := readResults(t, resultsPath)
+	if !slices.Equal(results[1], []string{statusOK}) || !slices.Equal(results[2], []string{statusFailed, statusOK}) || !slices.Equal(results[3], []string{statusOK}) {
+		t.Errorf("resultados %v após retomar", results)
+	}
+
+	// Sem --resume, o arquivo de resultados é recriado e tudo é enviado de novo
+	if err := runReplay(replayArgs(address, file, "--results", resultsPath)); err != nil {
+		t.Fatalf("runReplay: %v", err)
+	}
+	if got := server.received(); !slices.Equal(got, []string{"p1", "p2", "p3"}) {
+		t.Errorf("nova execução: servidor recebeu %v, esperado [p1 p2 p3]", got)
+	}
+	if results := readResults(t, resultsPath); len(results[2]) != 1 {
+		t.Errorf("arquivo de resultados não foi recriado: %v", results)
+	}
+}
+
+func TestReplayResumeCorruptResults(t *testing.T) {
+	server := &replayServer{}
+	address := startReplayServer(t, server)
+	dir := t.TempDir()
+	file := writeLines(t, dir, "notificacoes.jsonl", notification("p1"), notification("p2"))
+	resultsPath := writeLines(t, dir, "resultados.jsonl", `{"line": 1, "status": "ok"}`, `{"line": 2, "sta`)
+
+	// Um arquivo de resultados corrompido interrompe a execução, em vez de reenviar linhas já enviadas
+	err := runReplay(replayArgs(address, file, "--results", resultsPath, "--resume"))
+	if err == nil || !strings.Contains(err.Error(), "arquivo de resultados inválido") {
+		t.Errorf("runReplay: %v, esperado erro do arquivo de resultados", err)
+	}
+	if got := server.received(); len(got) != 0 {
+		t.Errorf("servidor recebeu %v, esperado nada", got)
+	}
+}
@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"context"
+
+	"google.golang.org/grpc/stats"
+)
+
+// CompressionObserver recebe, para cada requisição enviada, o método gRPC e os tamanhos em
+// bytes antes e depois da compressão; os dois são iguais quando a requisição não foi comprimida
+type CompressionObserver func(method string, uncompressed, compressed int)
+
+// compressionStats é o stats.Handler que entrega os tamanhos das requisições ao CompressionObserver
+type compressionStats struct {
+	observer CompressionObserver
+}
+
+// methodKey guarda no contexto de uma chamada o nome do método gRPC
+type methodKey struct{}
+
+func (h compressionStats) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, methodKey{}, info.FullMethodName)
+}
+
+func (h compressionStats) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if payload, ok := s.(*stats.OutPayload); ok && payload.IsClient() {
+		method, _ := ctx.Value(methodKey{}).(string)
+		h.observer(method, payload.Length, payload.CompressedLength)
+	}
+}
+
+func (h compressionStats) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h compressionStats) HandleConn(ctx context.Context, s stats.ConnStats) {}
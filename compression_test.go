@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCompressionObserver(t *testing.T) {
+	type sizes struct {
+		method                   string
+		uncompressed, compressed int
+	}
+	var mu sync.Mutex
+	var observed []sizes
+
+	server := &fakeServer{}
+	c, err := NewClient(
+		WithServerAddress(startServer(t, server)),
+		WithOrigin("test"),
+		WithTimeout(3*time.Second),
+		WithCompression("gzip"),
+		WithCompressionThreshold(1024),
+		WithCompressionObserver(func(method string, uncompressed, compressed int) {
+			mu.Lock()
+			defer mu.Unlock()
+			observed = append(observed, sizes{method, uncompressed, compressed})
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	// Negocia a versão do serviço antes das notificações observadas
+	if err := c.Notify(context.Background(), testData("p0")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	mu.Lock()
+	observed = nil
+	mu.Unlock()
+
+	small := testData("p1")
+	large := testData("p2")
+	large.Metadata = map[string]string{"summary": strings.Repeat("bounce ", 1000)}
+	for _, params := range []*Data{small, large} {
+		if err := c.Notify(context.Background(), params); err != nil {
+			t.Fatalf("Notify: %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(observed) != 2 {
+		t.Fatalf("%d requisições observadas, esperado 2: %+v", len(observed), observed)
+	}
+	for _, s := range observed {
+		if !strings.HasSuffix(s.method, "/Notify") {
+			t.Errorf("método %q, esperado .../Notify", s.method)
+		}
+	}
+	if s := observed[0]; s.compressed != s.uncompressed {
+		t.Errorf("requisição abaixo do limite: %d -> %d bytes, esperado sem compressão", s.uncompressed, s.compressed)
+	}
+	if s := observed[1]; s.uncompressed < 7000 || s.compressed >= s.uncompressed/10 {
+		t.Errorf("requisição comprimida: %d -> %d bytes", s.uncompressed, s.compressed)
+	}
+}
+
+func TestReloadReplacesCompressionObserver(t *testing.T) {
+	var first, second atomic.Int32
+	c, err := NewClient(
+		WithServerAddress(startServer(t, &fakeServer{})),
+		WithOrigin("test"),
+		WithTimeout(3*time.Second),
+		WithCompressionObserver(func(string, int, int) { first.Add(1) }),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Reload(WithCompressionObserver(func(string, int, int) { second.Add(1) })); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if err := c.Notify(context.Background(), testData("p1")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if first.Load() != 0 || second.Load() == 0 {
+		t.Errorf("observadores chamados %d e %d vezes, esperado apenas o novo", first.Load(), second.Load())
+	}
+}
@@ -0,0 +1,319 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Variáveis de ambiente lidas por NewClientFromEnv
+const (
+	EnvServerAddress        = "NOTIFY_SERVER_ADDRESS"
+	EnvLoadBalancing        = "NOTIFY_LOAD_BALANCING"
+	EnvOrigin               = "NOTIFY_ORIGIN"
+	EnvTimeout              = "NOTIFY_TIMEOUT"
+	EnvMaxRetries           = "NOTIFY_MAX_RETRIES"
+	EnvRetryInterval        = "NOTIFY_RETRY_INTERVAL"
+	EnvTLSCert              = "NOTIFY_TLS_CERT"
+	EnvCompression          = "NOTIFY_COMPRESSION"
+	EnvCompressionThreshold = "NOTIFY_COMPRESSION_THRESHOLD"
+	EnvBatchConcurrency     = "NOTIFY_BATCH_CONCURRENCY"
+	EnvSeverityRetries      = "NOTIFY_SEVERITY_RETRIES"
+	EnvMaxEventAge          = "NOTIFY_MAX_EVENT_AGE"
+	EnvAsyncQueueSize       = "NOTIFY_ASYNC_QUEUE_SIZE"
+	EnvAsyncWorkers         = "NOTIFY_ASYNC_WORKERS"
+	EnvAsyncMaxWait         = "NOTIFY_ASYNC_MAX_WAIT"
+	EnvDryRun               = "NOTIFY_DRY_RUN"
+	EnvShadowAddress        = "NOTIFY_SHADOW_ADDRESS"
+	EnvShadowConcurrency    = "NOTIFY_SHADOW_CONCURRENCY"
+)
+
+// Config representa a configuração do cliente em arquivo (JSON ou YAML) ou em variáveis de ambiente.
+// Campos vazios mantêm o valor padrão. Durações usam o formato de time.ParseDuration (ex.: "5s").
+// Opções que não podem ser serializadas, como interceptors e o servidor em processo,
+// devem ser passadas como Option.
+type Config struct {
+	ServerAddress        string   `json:"server_address" yaml:"server_address"`
+	ServerAddresses      []string `json:"server_addresses" yaml:"server_addresses"`
+	LoadBalancing        string   `json:"load_balancing" yaml:"load_balancing"`
+	Origin               string   `json:"origin" yaml:"origin"`
+	Timeout              string   `json:"timeout" yaml:"timeout"`
+	MaxRetries           *int     `json:"max_retries" yaml:"max_retries"`
+	RetryInterval        string   `json:"retry_interval" yaml:"retry_interval"`
+	TLSCertPath          string   `json:"tls_cert" yaml:"tls_cert"`
+	Compression          string   `json:"compression" yaml:"compression"`
+	CompressionThreshold *int     `json:"compression_threshold" yaml:"compression_threshold"`
+	BatchConcurrency     *int     `json:"batch_concurrency" yaml:"batch_concurrency"`
+	MaxEventAge          string   `json:"max_event_age" yaml:"max_event_age"`
+	AsyncQueueSize       *int     `json:"async_queue_size" yaml:"async_queue_size"`
+	AsyncWorkers         *int     `json:"async_workers" yaml:"async_workers"`
+	AsyncMaxWait         string   `json:"async_max_wait" yaml:"async_max_wait"`
+	DryRun               *bool    `json:"dry_run" yaml:"dry_run"`
+	ShadowAddress        string   `json:"shadow_address" yaml:"shadow_address"`
+	ShadowConcurrency    *int     `json:"shadow_concurrency" yaml:"shadow_concurrency"`
+
+	// Tentativas máximas por severidade, ex.: {"CRITICAL": 6, "INFO": 1}
+	SeverityRetries map[string]int `json:"severity_retries" yaml:"severity_retries"`
+}
+
+// NewClientFromEnv cria um cliente configurado pelas variáveis de ambiente NOTIFY_*.
+// As opções informadas são aplicadas depois das variáveis de ambiente e prevalecem sobre elas.
+func NewClientFromEnv(opts ...Option) (*NotifyClient, error) {
+	config, err := ConfigFromEnv()
+	return config.newClient(opts, err)
+}
+
+// NewClientFromFile cria um cliente configurado por um arquivo JSON ou YAML.
+// As opções informadas são aplicadas depois do arquivo e prevalecem sobre ele.
+func NewClientFromFile(path string, opts ...Option) (*NotifyClient, error) {
+	config, err := LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := config.newClient(opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ao recarregar o arquivo, as opções informadas continuam prevalecendo sobre ele
+	c.baseOpts, c.overrideOpts = nil, slices.Clip(opts)
+	return c, nil
+}
+
+// ConfigFromEnv lê a configuração das variáveis de ambiente NOTIFY_*.
+// NOTIFY_SERVER_ADDRESS aceita vários endereços separados por vírgula e
+// NOTIFY_SEVERITY_RETRIES pares severidade=tentativas separados por vírgula (ex.: "CRITICAL=6,INFO=1").
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{
+		LoadBalancing: os.Getenv(EnvLoadBalancing),
+		Origin:        os.Getenv(EnvOrigin),
+		Timeout:       os.Getenv(EnvTimeout),
+		RetryInterval: os.Getenv(EnvRetryInterval),
+		TLSCertPath:   os.Getenv(EnvTLSCert),
+		Compression:   os.Getenv(EnvCompression),
+		MaxEventAge:   os.Getenv(EnvMaxEventAge),
+		AsyncMaxWait:  os.Getenv(EnvAsyncMaxWait),
+		ShadowAddress: os.Getenv(EnvShadowAddress),
+	}
+
+	if addresses := splitAddresses(os.Getenv(EnvServerAddress)); len(addresses) > 1 {
+		config.ServerAddresses = addresses
+	} else if len(addresses) == 1 {
+		config.ServerAddress = addresses[0]
+	}
+
+	var errs []error
+	var err error
+	if config.MaxRetries, err = envInt(EnvMaxRetries); err != nil {
+		errs = append(errs, err)
+	}
+	if config.CompressionThreshold, err = envInt(EnvCompressionThreshold); err != nil {
+		errs = append(errs, err)
+	}
+	if config.BatchConcurrency, err = envInt(EnvBatchConcurrency); err != nil {
+		errs = append(errs, err)
+	}
+	if config.AsyncQueueSize, err = envInt(EnvAsyncQueueSize); err != nil {
+		errs = append(errs, err)
+	}
+	if config.AsyncWorkers, err = envInt(EnvAsyncWorkers); err != nil {
+		errs = append(errs, err)
+	}
+	if config.DryRun, err = envBool(EnvDryRun); err != nil {
+		errs = append(errs, err)
+	}
+	if config.ShadowConcurrency, err = envInt(EnvShadowConcurrency); err != nil {
+		errs = append(errs, err)
+	}
+	if config.SeverityRetries, err = envSeverityRetries(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return config, errors.Join(errs...)
+}
+
+// LoadConfigFile lê a configuração de um arquivo JSON (.json) ou YAML (.yaml, .yml).
+// Campos desconhecidos são rejeitados para que erros de digitação não passem despercebidos.
+func LoadConfigFile(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler arquivo de configuração: %w", err)
+	}
+
+	config := &Config{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(config)
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(config)
+	default:
+		return nil, fmt.Errorf("formato de arquivo de configuração não suportado: %s. Use .json, .yaml ou .yml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("arquivo de configuração inválido %s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// Options converte a configuração em opções do cliente.
+// Todos os valores inválidos são reportados de uma vez; os válidos continuam sendo retornados.
+func (c *Config) Options() ([]Option, error) {
+	var opts []Option
+	var errs []error
+
+	if len(c.ServerAddresses) > 0 {
+		opts = append(opts, WithServerAddresses(c.ServerAddresses...))
+	} else if c.ServerAddress != "" {
+		opts = append(opts, WithServerAddress(c.ServerAddress))
+	}
+	if c.LoadBalancing != "" {
+		opts = append(opts, WithLoadBalancing(strings.ToLower(c.LoadBalancing)))
+	}
+	if c.Origin != "" {
+		opts = append(opts, WithOrigin(c.Origin))
+	}
+	if c.Timeout != "" {
+		if timeout, err := time.ParseDuration(c.Timeout); err != nil {
+			errs = append(errs, fmt.Errorf("timeout inválido %q: %w", c.Timeout, err))
+		} else {
+			opts = append(opts, WithTimeout(timeout))
+		}
+	}
+	if c.MaxRetries != nil {
+		opts = append(opts, WithMaxRetries(*c.MaxRetries))
+	}
+	if c.RetryInterval != "" {
+		if interval, err := time.ParseDuration(c.RetryInterval); err != nil {
+			errs = append(errs, fmt.Errorf("intervalo entre tentativas inválido %q: %w", c.RetryInterval, err))
+		} else {
+			opts = append(opts, WithRetryInterval(interval))
+		}
+	}
+	if c.TLSCertPath != "" {
+		opts = append(opts, WithTLS(c.TLSCertPath))
+	}
+	if c.Compression != "" {
+		opts = append(opts, WithCompression(c.Compression))
+	}
+	if c.CompressionThreshold != nil {
+		opts = append(opts, WithCompressionThreshold(*c.CompressionThreshold))
+	}
+	if c.BatchConcurrency != nil {
+		opts = append(opts, WithBatchConcurrency(*c.BatchConcurrency))
+	}
+	if c.MaxEventAge != "" {
+		if age, err := time.ParseDuration(c.MaxEventAge); err != nil {
+			errs = append(errs, fmt.Errorf("idade máxima das notificações inválida %q: %w", c.MaxEventAge, err))
+		} else {
+			opts = append(opts, WithMaxEventAge(age))
+		}
+	}
+	if c.AsyncQueueSize != nil {
+		opts = append(opts, WithAsyncQueueSize(*c.AsyncQueueSize))
+	}
+	if c.AsyncWorkers != nil {
+		opts = append(opts, WithAsyncWorkers(*c.AsyncWorkers))
+	}
+	if c.AsyncMaxWait != "" {
+		if wait, err := time.ParseDuration(c.AsyncMaxWait); err != nil {
+			errs = append(errs, fmt.Errorf("espera máxima na fila assíncrona inválida %q: %w", c.AsyncMaxWait, err))
+		} else {
+			opts = append(opts, WithAsyncMaxWait(wait))
+		}
+	}
+	if c.DryRun != nil && *c.DryRun {
+		opts = append(opts, WithDryRun())
+	}
+	if c.ShadowAddress != "" {
+		opts = append(opts, WithShadow(c.ShadowAddress))
+	}
+	if c.ShadowConcurrency != nil {
+		opts = append(opts, WithShadowConcurrency(*c.ShadowConcurrency))
+	}
+	for severity, retries := range c.SeverityRetries {
+		opts = append(opts, WithSeverityRetries(strings.ToUpper(severity), retries))
+	}
+
+	return opts, errors.Join(errs...)
+}
+
+// newClient cria o cliente a partir da configuração e das opções adicionais.
+// Erros de leitura, de conversão e de validação são reportados juntos, sem abrir conexão.
+func (c *Config) newClient(extra []Option, loadErr error) (*NotifyClient, error) {
+	opts, err := c.Options()
+	opts = append(opts, extra...)
+	if err = errors.Join(loadErr, err); err != nil {
+		return nil, errors.Join(err, applyOptions(opts).Validate())
+	}
+	return NewClient(opts...)
+}
+
+// envInt lê uma variável de ambiente inteira, retornando nil quando ela não está definida
+func envInt(name string) (*int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s inválido %q: deve ser um número inteiro", name, value)
+	}
+	return &n, nil
+}
+
+// envBool lê uma variável de ambiente booleana, retornando nil quando ela não está definida
+func envBool(name string) (*bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s inválido %q: use true ou false", name, value)
+	}
+	return &b, nil
+}
+
+// splitAddresses separa uma lista de endereços por vírgula, ignorando espaços e itens vazios
+func splitAddresses(value string) []string {
+	var addresses []string
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// envSeverityRetries lê NOTIFY_SEVERITY_RETRIES, retornando nil quando ela não está definida
+func envSeverityRetries() (map[string]int, error) {
+	value := os.Getenv(EnvSeverityRetries)
+	if value == "" {
+		return nil, nil
+	}
+
+	retries := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		severity, n, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		count, err := strconv.Atoi(n)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("%s inválido %q: use o formato SEVERIDADE=tentativas, separados por vírgula", EnvSeverityRetries, value)
+		}
+		retries[strings.ToUpper(severity)] = count
+	}
+	return retries, nil
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig grava o conteúdo em um arquivo temporário com o nome informado e retorna o caminho
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    *Config
+		wantErr []string
+	}{
+		{
+			name: "vazio",
+			env:  map[string]string{},
+			want: &Config{},
+		},
+		{
+			name: "um endereço",
+			env:  map[string]string{EnvServerAddress: " notify:50051 ", EnvOrigin: "api", EnvTimeout: "5s"},
+			want: &Config{ServerAddress: "notify:50051", Origin: "api", Timeout: "5s"},
+		},
+		{
+			name: "itens vazios na lista de endereços",
+			env:  map[string]string{EnvServerAddress: " a:1 , ,b:2,"},
+			want: &Config{ServerAddresses: []string{"a:1", "b:2"}},
+		},
+		{
+			name: "um endereço com vírgula ao final",
+			env:  map[string]string{EnvServerAddress: "a:1, "},
+			want: &Config{ServerAddress: "a:1"},
+		},
+		{
+			name: "vários endereços e valores numéricos",
+			env: map[string]string{
+				EnvServerAddress:     "a:1, b:2",
+				EnvMaxRetries:        "0",
+				EnvBatchConcurrency:  "16",
+				EnvDryRun:            "true",
+				EnvSeverityRetries:   "critical=6, INFO=1",
+				EnvShadowConcurrency: "4",
+			},
+			want: &Config{
+				ServerAddresses:   []string{"a:1", "b:2"},
+				MaxRetries:        intPtr(0),
+				BatchConcurrency:  intPtr(16),
+				DryRun:            boolPtr(true),
+				SeverityRetries:   map[string]int{CRITICAL: 6, INFO: 1},
+				ShadowConcurrency: intPtr(4),
+			},
+		},
+		{
+			name: "valores inválidos reportados juntos",
+			env: map[string]string{
+				EnvMaxRetries:      "três",
+				EnvAsyncWorkers:    "1.5",
+				EnvDryRun:          "talvez",
+				EnvSeverityRetries: "CRITICAL:6",
+			},
+			wantErr: []string{EnvMaxRetries, EnvAsyncWorkers, EnvDryRun, EnvSeverityRetries},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{EnvServerAddress, EnvOrigin, EnvTimeout, EnvMaxRetries, EnvBatchConcurrency, EnvAsyncWorkers, EnvDryRun, EnvSeverityRetries, EnvShadowConcurrency} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			config, err := ConfigFromEnv()
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("esperado erro")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("erro sem %s: %v", want, err)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConfigFromEnv: %v", err)
+			}
+			if !reflect.DeepEqual(config, tt.want) {
+				t.Errorf("ConfigFromEnv = %+v, esperado %+v", config, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	jsonConfig := `{"server_addresses": ["a:1", "b:2"], "origin": "api", "timeout": "5s", "max_retries": 2, "dry_run": true, "severity_retries": {"CRITICAL": 6}}`
+	yamlConfig := "server_addresses: [a:1, b:2]\norigin: api\ntimeout: 5s\nmax_retries: 2\ndry_run: true\nseverity_retries:\n  CRITICAL: 6\n"
+
+	var configs []*Config
+	for _, file := range []struct{ name, content string }{
+		{"config.json", jsonConfig},
+		{"config.yaml", yamlConfig},
+		{"config.YML", yamlConfig},
+	} {
+		config, err := LoadConfigFile(writeConfig(t, file.name, file.content))
+		if err != nil {
+			t.Fatalf("%s: %v", file.name, err)
+		}
+		configs = append(configs, config)
+	}
+	for i, config := range configs[1:] {
+		if !reflect.DeepEqual(config, configs[0]) {
+			t.Errorf("configuração %d difere do JSON: %+v, esperado %+v", i+1, config, configs[0])
+		}
+	}
+	if c := configs[0]; c.Origin != "api" || *c.MaxRetries != 2 || c.SeverityRetries[CRITICAL] != 6 {
+		t.Errorf("configuração inesperada: %+v", c)
+	}
+
+	invalid := []struct{ name, content, want string }{
+		{"campo.json", `{"orign": "api"}`, "orign"},
+		{"campo.yaml", "orign: api\n", "orign"},
+		{"sintaxe.json", `{"origin": `, "inválido"},
+		{"config.toml", `origin = "api"`, "não suportado"},
+	}
+	for _, tt := range invalid {
+		if _, err := LoadConfigFile(writeConfig(t, tt.name, tt.content)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: %v, esperado erro com %q", tt.name, err, tt.want)
+		}
+	}
+	if _, err := LoadConfigFile(filepath.Join(t.TempDir(), "ausente.yaml")); err == nil {
+		t.Error("arquivo ausente aceito")
+	}
+}
+
+func TestConfigOptionsJoinsErrors(t *testing.T) {
+	config := &Config{
+		Origin:        "api",
+		Timeout:       "dez segundos",
+		RetryInterval: "1x",
+		MaxEventAge:   "ontem",
+		AsyncMaxWait:  "2m",
+	}
+
+	opts, err := config.Options()
+	if err == nil {
+		t.Fatal("esperado erro")
+	}
+	for _, want := range []string{`timeout inválido "dez segundos"`, `"1x"`, `"ontem"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("erro sem %s: %v", want, err)
+		}
+	}
+
+	// Os valores válidos continuam sendo convertidos
+	options := applyOptions(opts)
+	if options.Origin != "api" || options.AsyncMaxWait != 2*time.Minute {
+		t.Errorf("opções válidas não aplicadas: origem %q, AsyncMaxWait %s", options.Origin, options.AsyncMaxWait)
+	}
+}
+
+func TestNewClientFromFileReportsAllErrors(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "timeout: 1x\nbatch_concurrency: 0\n")
+
+	_, err := NewClientFromFile(path)
+	if err == nil {
+		t.Fatal("esperado erro")
+	}
+	// Erros de conversão e de validação aparecem juntos
+	for _, want := range []string{"timeout inválido", "BatchConcurrency", "Origin"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("erro sem %s: %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// ErrContextConflict é retornado quando um campo de Data difere do valor definido no contexto
+var ErrContextConflict = errors.New("parâmetros conflitam com o contexto")
+
+// Chaves dos valores guardados no contexto
+type (
+	projectContextKey  struct{}
+	scopeContextKey    struct{}
+	metadataContextKey struct{}
+)
+
+// WithProject retorna um contexto com o projeto usado pelas notificações que não informam ProjectID
+func WithProject(ctx context.Context, projectID string) context.Context {
+	return context.WithValue(ctx, projectContextKey{}, projectID)
+}
+
+// WithScope retorna um contexto com o escopo usado pelas notificações que não informam Scope
+func WithScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// WithMetadata retorna um contexto com metadados acrescentados a todas as notificações enviadas
+// com ele. Chamadas aninhadas acumulam os metadados; em chaves repetidas, prevalece o mais interno.
+func WithMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	merged := maps.Clone(MetadataFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(metadata))
+	}
+	maps.Copy(merged, metadata)
+	return context.WithValue(ctx, metadataContextKey{}, merged)
+}
+
+// ProjectFromContext retorna o projeto definido por WithProject, ou vazio
+func ProjectFromContext(ctx context.Context) string {
+	projectID, _ := ctx.Value(projectContextKey{}).(string)
+	return projectID
+}
+
+// ScopeFromContext retorna o escopo definido por WithScope, ou vazio
+func ScopeFromContext(ctx context.Context) string {
+	scope, _ := ctx.Value(scopeContextKey{}).(string)
+	return scope
+}
+
+// MetadataFromContext retorna os metadados definidos por WithMetadata, ou nil.
+// O mapa retornado não deve ser alterado.
+func MetadataFromContext(ctx context.Context) map[string]string {
+	metadata, _ := ctx.Value(metadataContextKey{}).(map[string]string)
+	return metadata
+}
+
+// fromContext preenche ProjectID, Scope e Metadata com os valores do contexto, sem alterar os
+// parâmetros do chamador. Valores já informados em Data que diferem do contexto retornam
+// ErrContextConflict, com todos os conflitos encontrados.
+func (np *Data) fromContext(ctx context.Context) (*Data, error) {
+	projectID, scope, metadata := ProjectFromContext(ctx), ScopeFromContext(ctx), MetadataFromContext(ctx)
+	if projectID == "" && scope == "" && len(metadata) == 0 {
+		return np, nil
+	}
+
+	var errs []error
+	c := np.clone()
+
+	if projectID != "" {
+		if c.ProjectID == "" {
+			c.ProjectID = projectID
+		} else if c.ProjectID != projectID {
+			errs = append(errs, fmt.Errorf("%w: ProjectID %q, contexto %q", ErrContextConflict, c.ProjectID, projectID))
+		}
+	}
+
+	if scope != "" {
+		if c.Scope == "" {
+			c.Scope = scope
+		} else if c.Scope != scope {
+			errs = append(errs, fmt.Errorf("%w: Scope %q, contexto %q", ErrContextConflict, c.Scope, scope))
+		}
+	}
+
+	for k, v := range metadata {
+		if current, ok := c.Metadata[k]; ok {
+			if current != v {
+				errs = append(errs, fmt.Errorf("%w: Metadata[%q] %q, contexto %q", ErrContextConflict, k, current, v))
+			}
+			continue
+		}
+		if _, ok := c.StructuredMetadata[k]; ok {
+			errs = append(errs, fmt.Errorf("%w: StructuredMetadata[%q] também definido no contexto", ErrContextConflict, k))
+			continue
+		}
+		if c.Metadata == nil {
+			c.Metadata = make(map[string]string, len(metadata))
+		}
+		c.Metadata[k] = v
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
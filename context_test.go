@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContextDefaults(t *testing.T) {
+	ctx := WithProject(context.Background(), "p1")
+	ctx = WithScope(ctx, SYSTEM)
+	ctx = WithMetadata(ctx, map[string]string{"tenant": "t1", "region": "sa"})
+	ctx = WithMetadata(ctx, map[string]string{"region": "us", "request": "r1"})
+
+	tests := []struct {
+		name      string
+		params    *Data
+		want      *Data
+		conflicts []string
+	}{
+		{
+			name:   "campos ausentes vêm do contexto",
+			params: &Data{Type: BOUNCE},
+			want:   &Data{ProjectID: "p1", Scope: SYSTEM, Type: BOUNCE, Metadata: map[string]string{"tenant": "t1", "region": "us", "request": "r1"}},
+		},
+		{
+			name:   "valores iguais ao contexto são aceitos",
+			params: &Data{ProjectID: "p1", Scope: SYSTEM, Type: BOUNCE, Metadata: map[string]string{"tenant": "t1", "campaign": "c1"}},
+			want:   &Data{ProjectID: "p1", Scope: SYSTEM, Type: BOUNCE, Metadata: map[string]string{"tenant": "t1", "region": "us", "request": "r1", "campaign": "c1"}},
+		},
+		{
+			// Um valor explícito diferente do contexto não prevalece nem é sobrescrito: o envio falha
+			name:      "valores explícitos em conflito",
+			params:    &Data{ProjectID: "p2", Scope: CAMPAIGN, Type: BOUNCE, Metadata: map[string]string{"region": "sa"}},
+			conflicts: []string{`ProjectID "p2", contexto "p1"`, `Scope "CAMPAIGN", contexto "SYSTEM"`, `Metadata["region"] "sa", contexto "us"`},
+		},
+		{
+			name:      "chave do contexto em StructuredMetadata",
+			params:    &Data{Type: BOUNCE, StructuredMetadata: map[string]any{"tenant": 1.0}},
+			conflicts: []string{`StructuredMetadata["tenant"]`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.params.clone()
+
+			got, err := tt.params.fromContext(ctx)
+			if !reflect.DeepEqual(tt.params, original) {
+				t.Errorf("parâmetros do chamador alterados: %+v", tt.params)
+			}
+			if len(tt.conflicts) > 0 {
+				if !errors.Is(err, ErrContextConflict) {
+					t.Fatalf("fromContext: %v, esperado ErrContextConflict", err)
+				}
+				for _, conflict := range tt.conflicts {
+					if !strings.Contains(err.Error(), conflict) {
+						t.Errorf("erro sem o conflito %s: %v", conflict, err)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fromContext: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fromContext = %+v, esperado %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotifyContextConflictNotSent(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server)
+	ctx := WithProject(context.Background(), "p1")
+
+	if err := c.Notify(ctx, testData("p2")); !errors.Is(err, ErrContextConflict) {
+		t.Errorf("Notify: %v, esperado ErrContextConflict", err)
+	}
+	if calls := server.calls.Load(); calls != 0 {
+		t.Errorf("%d chamadas ao servidor, esperado 0", calls)
+	}
+
+	if err := c.Notify(ctx, &Data{Scope: SYSTEM, Type: BOUNCE}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if received := server.received(); len(received) != 1 || received[0].ProjectId != "p1" {
+		t.Errorf("requisições recebidas: %v, esperado o projeto do contexto", received)
+	}
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// Cliente padrão do pacote, usado por Send
+var (
+	defaultMu     sync.RWMutex
+	defaultClient *NotifyClient
+)
+
+// SetDefault define o cliente padrão usado por Default e Send.
+// Útil para configurar o cliente explicitamente ou substituí-lo em testes;
+// passar nil faz com que o próximo Default crie um novo cliente a partir do ambiente.
+// O cliente anterior não é fechado.
+func SetDefault(c *NotifyClient) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient = c
+}
+
+// Default retorna o cliente padrão. Na primeira chamada, se nenhum cliente tiver sido
+// definido com SetDefault, ele é criado com NewClientFromEnv. Em caso de erro, a criação
+// é tentada novamente na próxima chamada.
+func Default() (*NotifyClient, error) {
+	defaultMu.RLock()
+	c := defaultClient
+	defaultMu.RUnlock()
+	if c != nil {
+		return c, nil
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	// Outra goroutine pode ter criado o cliente enquanto aguardávamos o lock
+	if defaultClient != nil {
+		return defaultClient, nil
+	}
+
+	c, err := NewClientFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	defaultClient = c
+	return c, nil
+}
+
+// Send envia uma notificação usando o cliente padrão
+func Send(ctx context.Context, params *Data) error {
+	c, err := Default()
+	if err != nil {
+		return err
+	}
+	return c.Notify(ctx, params)
+}
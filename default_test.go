@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// resetDefault limpa o cliente padrão e o fecha ao final do teste
+func resetDefault(t *testing.T) {
+	t.Helper()
+
+	SetDefault(nil)
+	t.Cleanup(func() {
+		defaultMu.Lock()
+		defer defaultMu.Unlock()
+		if defaultClient != nil {
+			defaultClient.Close()
+			defaultClient = nil
+		}
+	})
+}
+
+func TestDefaultFromEnv(t *testing.T) {
+	resetDefault(t)
+	server := &fakeServer{}
+	t.Setenv(EnvServerAddress, startServer(t, server))
+	t.Setenv(EnvOrigin, "test")
+
+	// Um ambiente inválido retorna erro e a criação é tentada novamente na próxima chamada
+	t.Setenv(EnvTimeout, "abc")
+	if c, err := Default(); err == nil {
+		t.Fatalf("Default = %v, esperado erro de NOTIFY_TIMEOUT", c)
+	}
+
+	t.Setenv(EnvTimeout, "3s")
+	c, err := Default()
+	if err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	if again, _ := Default(); again != c {
+		t.Error("Default criou um novo cliente na segunda chamada")
+	}
+	if c.state.Load().options.Origin != "test" {
+		t.Errorf("Origin = %q, esperado o de NOTIFY_ORIGIN", c.state.Load().options.Origin)
+	}
+
+	if err := Send(context.Background(), testData("p1")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if received := server.received(); len(received) != 1 || received[0].ProjectId != "p1" {
+		t.Errorf("requisições recebidas: %v, esperado p1", received)
+	}
+}
+
+func TestDefaultConcurrent(t *testing.T) {
+	resetDefault(t)
+	t.Setenv(EnvServerAddress, startServer(t, &fakeServer{}))
+	t.Setenv(EnvOrigin, "test")
+
+	// Chamadas concorrentes criam um único cliente
+	clients := make([]*NotifyClient, 20)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := Default()
+			if err != nil {
+				t.Errorf("Default: %v", err)
+			}
+			clients[i] = c
+		}()
+	}
+	wg.Wait()
+	for _, c := range clients {
+		if c != clients[0] {
+			t.Fatal("Default retornou clientes diferentes em chamadas concorrentes")
+		}
+	}
+
+	// SetDefault concorrente com Default: cada chamada vê um dos clientes definidos
+	server := &fakeServer{}
+	set := []*NotifyClient{newTestClient(t, server), newTestClient(t, server)}
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefault(set[i%2])
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := Default(); err != nil {
+				t.Errorf("Default: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	c, err := Default()
+	if err != nil {
+		t.Fatalf("Default: %v", err)
+	}
+	if c != set[0] && c != set[1] {
+		t.Error("Default não retornou um dos clientes definidos com SetDefault")
+	}
+	clients[0].Close()
+}
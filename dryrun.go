@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// DryRunRecorder recebe as notificações que seriam enviadas no modo dry run, já preenchidas
+// pelo contexto, validadas, enriquecidas e processadas pelos middlewares
+type DryRunRecorder func(ctx context.Context, params *Data)
+
+// dryRun executa todas as verificações do envio, inclusive a conversão para as requisições
+// das duas versões do serviço, e entrega a notificação ao DryRunRecorder em vez de enviá-la
+func (c *NotifyClient) dryRun(ctx context.Context, state *clientState, params *Data) (*Result, error) {
+	if err := state.options.checkEventAge(params); err != nil {
+		return nil, err
+	}
+	if _, err := params.toGRPCRequestV2(state.options.Origin); err != nil {
+		return nil, fmt.Errorf("parâmetros inválidos: %w", err)
+	}
+	if _, err := params.toGRPCRequest(state.options.Origin); err != nil {
+		return nil, fmt.Errorf("parâmetros inválidos: %w", err)
+	}
+
+	record := state.options.DryRunRecorder
+	if record == nil {
+		record = logDryRun
+	}
+	record(ctx, params)
+
+	return &Result{CreatedAt: time.Now()}, nil
+}
+
+// logDryRun é o DryRunRecorder padrão: registra a notificação no log padrão
+func logDryRun(ctx context.Context, params *Data) {
+	content, err := json.Marshal(params)
+	if err != nil {
+		log.Printf("notify: dry run, notificação não enviada: %+v", params)
+		return
+	}
+	log.Printf("notify: dry run, notificação não enviada: %s", content)
+}
+
+// JSONLRecorder retorna um DryRunRecorder que grava as notificações em w no formato JSON Lines,
+// que pode ser lido por NewJSONLReader para reenviá-las. Erros de escrita são ignorados.
+func JSONLRecorder(w io.Writer) DryRunRecorder {
+	var mu sync.Mutex
+	return func(ctx context.Context, params *Data) {
+		content, err := json.Marshal(params)
+		if err != nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Write(append(content, '\n'))
+	}
+}
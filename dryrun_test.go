@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRunNoRPC(t *testing.T) {
+	var mu sync.Mutex
+	var recorded []*Data
+	var middlewareCalls atomic.Int32
+
+	server := &fakeServer{}
+	c := newTestClient(t, server,
+		WithDryRun(),
+		WithDryRunRecorder(func(ctx context.Context, params *Data) {
+			mu.Lock()
+			defer mu.Unlock()
+			recorded = append(recorded, params)
+		}),
+		WithEnrichers(EnvironmentEnricher("staging")),
+		WithMiddleware(func(next Handler) Handler {
+			return func(ctx context.Context, params *Data) (*Result, error) {
+				middlewareCalls.Add(1)
+				params = params.clone()
+				params.Metadata["middleware"] = "sim"
+				return next(ctx, params)
+			}
+		}),
+	)
+
+	result, err := c.NotifyWithResult(context.Background(), testData("p1"))
+	if err != nil {
+		t.Fatalf("NotifyWithResult: %v", err)
+	}
+	if result.CreatedAt.IsZero() {
+		t.Error("resultado do dry run sem CreatedAt")
+	}
+
+	// Notificações inválidas continuam sendo rejeitadas, antes dos middlewares e do recorder
+	if err := c.Notify(context.Background(), &Data{ProjectID: "p1", Scope: "OUTRO", Type: BOUNCE}); err == nil {
+		t.Error("notificação inválida aceita no dry run")
+	}
+
+	// Em NotifyBatch, cada notificação passa pela cadeia e também não é enviada
+	if _, err := c.NotifyBatch(context.Background(), []*Data{testData("p2"), testData("p3")}); err != nil {
+		t.Fatalf("NotifyBatch: %v", err)
+	}
+
+	if calls := server.calls.Load(); calls != 0 {
+		t.Errorf("%d chamadas ao servidor no dry run, esperado 0", calls)
+	}
+	if calls := middlewareCalls.Load(); calls != 3 {
+		t.Errorf("middleware executado %d vezes, esperado 3", calls)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(recorded) != 3 {
+		t.Fatalf("%d notificações registradas, esperado 3", len(recorded))
+	}
+	for _, params := range recorded {
+		if params.Metadata[MetadataEnvironment] != "staging" || params.Metadata["middleware"] != "sim" || params.OccurredAt.IsZero() {
+			t.Errorf("notificação registrada sem os passos da cadeia: %+v", params)
+		}
+	}
+}
+
+func TestJSONLRecorderReplay(t *testing.T) {
+	var buf bytes.Buffer
+	c := newTestClient(t, &fakeServer{}, WithDryRun(), WithDryRunRecorder(JSONLRecorder(&buf)))
+
+	for _, projectID := range []string{"p1", "p2"} {
+		if err := c.Notify(context.Background(), testData(projectID)); err != nil {
+			t.Fatalf("Notify: %v", err)
+		}
+	}
+
+	// O arquivo gravado pode ser lido de volta para reenviar as notificações
+	reader := NewJSONLReader(&buf)
+	for _, want := range []string{"p1", "p2"} {
+		line, err := reader.Next()
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		if line.Err != nil || line.Data.ProjectID != want || line.Data.OccurredAt.IsZero() {
+			t.Errorf("linha %d: %+v, esperado %s com OccurredAt", line.Number, line, want)
+		}
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("Next ao final: %v, esperado io.EOF", err)
+	}
+}
@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"runtime/debug"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Chaves de metadados preenchidas pelos enrichers da biblioteca
+const (
+	MetadataHost           = "host"
+	MetadataServiceVersion = "service_version"
+	MetadataVCSRevision    = "vcs_revision"
+	MetadataEnvironment    = "environment"
+	MetadataTraceID        = "trace_id"
+	MetadataSpanID         = "span_id"
+)
+
+// Enricher retorna metadados a acrescentar a uma notificação antes do envio.
+// Os metadados retornados nunca sobrescrevem chaves já presentes em Metadata ou
+// StructuredMetadata, sejam elas do chamador ou de um enricher registrado antes.
+type Enricher func(ctx context.Context, params *Data) map[string]string
+
+// HostEnricher acrescenta o nome da máquina em "host"
+func HostEnricher() Enricher {
+	host, err := os.Hostname()
+	return func(ctx context.Context, params *Data) map[string]string {
+		if err != nil {
+			return nil
+		}
+		return map[string]string{MetadataHost: host}
+	}
+}
+
+// BuildInfoEnricher acrescenta a versão do módulo principal em "service_version" e a revisão
+// do controle de versão em "vcs_revision", conforme registradas no binário por debug.ReadBuildInfo
+func BuildInfoEnricher() Enricher {
+	metadata := sync.OnceValue(func() map[string]string {
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return nil
+		}
+
+		metadata := make(map[string]string)
+		if version := info.Main.Version; version != "" && version != "(devel)" {
+			metadata[MetadataServiceVersion] = version
+		}
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				metadata[MetadataVCSRevision] = setting.Value
+			}
+		}
+		return metadata
+	})
+
+	return func(ctx context.Context, params *Data) map[string]string {
+		return metadata()
+	}
+}
+
+// EnvironmentEnricher acrescenta o nome do ambiente (ex.: "production") em "environment"
+func EnvironmentEnricher(name string) Enricher {
+	return func(ctx context.Context, params *Data) map[string]string {
+		return map[string]string{MetadataEnvironment: name}
+	}
+}
+
+// TraceEnricher acrescenta os IDs do trace e do span do OpenTelemetry presentes no contexto
+// em "trace_id" e "span_id"
+func TraceEnricher() Enricher {
+	return func(ctx context.Context, params *Data) map[string]string {
+		span := trace.SpanContextFromContext(ctx)
+		if !span.IsValid() {
+			return nil
+		}
+		return map[string]string{
+			MetadataTraceID: span.TraceID().String(),
+			MetadataSpanID:  span.SpanID().String(),
+		}
+	}
+}
+
+// ContextValueEnricher acrescenta em metadataKey o valor associado a ctxKey no contexto,
+// como o ID da requisição guardado por um middleware HTTP. Nada é acrescentado se o valor não existir.
+func ContextValueEnricher(metadataKey string, ctxKey any) Enricher {
+	return func(ctx context.Context, params *Data) map[string]string {
+		value := ctx.Value(ctxKey)
+		if value == nil {
+			return nil
+		}
+		return map[string]string{metadataKey: fmt.Sprint(value)}
+	}
+}
+
+// enrich executa os enrichers e retorna os parâmetros com os metadados acrescentados,
+// sem alterar os do chamador
+func enrich(ctx context.Context, params *Data, enrichers []Enricher) *Data {
+	if len(enrichers) == 0 {
+		return params
+	}
+
+	metadata := maps.Clone(params.Metadata)
+	for _, enricher := range enrichers {
+		for k, v := range enricher(ctx, params) {
+			if _, ok := metadata[k]; ok {
+				continue
+			}
+			if _, ok := params.StructuredMetadata[k]; ok {
+				continue
+			}
+			if metadata == nil {
+				metadata = make(map[string]string)
+			}
+			metadata[k] = v
+		}
+	}
+
+	enriched := *params
+	enriched.Metadata = metadata
+	return &enriched
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+// staticEnricher retorna sempre os mesmos metadados
+func staticEnricher(metadata map[string]string) Enricher {
+	return func(ctx context.Context, params *Data) map[string]string {
+		return metadata
+	}
+}
+
+func TestEnricherPrecedence(t *testing.T) {
+	first := staticEnricher(map[string]string{"environment": "staging", "host": "h1", "lote": "enricher"})
+	second := staticEnricher(map[string]string{"environment": "production", "region": "sa"})
+
+	tests := []struct {
+		name       string
+		metadata   map[string]string
+		structured map[string]any
+		enrichers  []Enricher
+		want       map[string]string
+	}{
+		{
+			name:      "sem metadados do chamador",
+			enrichers: []Enricher{first},
+			want:      map[string]string{"environment": "staging", "host": "h1", "lote": "enricher"},
+		},
+		{
+			name:      "o chamador prevalece sobre os enrichers",
+			metadata:  map[string]string{"environment": "dev"},
+			enrichers: []Enricher{first, second},
+			want:      map[string]string{"environment": "dev", "host": "h1", "lote": "enricher", "region": "sa"},
+		},
+		{
+			name:      "o primeiro enricher prevalece sobre os seguintes",
+			enrichers: []Enricher{first, second},
+			want:      map[string]string{"environment": "staging", "host": "h1", "lote": "enricher", "region": "sa"},
+		},
+		{
+			name:       "chaves de StructuredMetadata não são repetidas",
+			structured: map[string]any{"lote": 42.0},
+			enrichers:  []Enricher{first},
+			want:       map[string]string{"environment": "staging", "host": "h1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := testData("p1")
+			params.Metadata = maps.Clone(tt.metadata)
+			params.StructuredMetadata = tt.structured
+
+			enriched := enrich(context.Background(), params, tt.enrichers)
+			if !maps.Equal(enriched.Metadata, tt.want) {
+				t.Errorf("Metadata = %v, esperado %v", enriched.Metadata, tt.want)
+			}
+			if !maps.Equal(params.Metadata, tt.metadata) {
+				t.Errorf("metadados do chamador alterados: %v", params.Metadata)
+			}
+		})
+	}
+}
+
+func TestEnrichersWithContextMetadata(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, WithEnrichers(
+		staticEnricher(map[string]string{"tenant": "enricher", "environment": "production"}),
+	))
+
+	// Metadados do contexto contam como do chamador e também prevalecem sobre os enrichers
+	ctx := WithMetadata(context.Background(), map[string]string{"tenant": "t1"})
+	params := testData("p1")
+	params.Metadata = map[string]string{"environment": "dev"}
+	if err := c.Notify(ctx, params); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	received := server.received()
+	if len(received) != 1 {
+		t.Fatalf("%d requisições, esperado 1", len(received))
+	}
+	want := map[string]string{"tenant": "t1", "environment": "dev"}
+	if !maps.Equal(received[0].Metadata, want) {
+		t.Errorf("Metadata = %v, esperado %v", received[0].Metadata, want)
+	}
+}
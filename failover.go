@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+)
+
+// Intervalo entre as tentativas de acompanhar o health check de um endereço que falhou
+const healthRetryInterval = time.Second
+
+// healthFailover faz o failover por health check da política PICK_FIRST. O pick_first do
+// gRPC não consulta o health check e mantém a conexão com um endereço que responde
+// NOT_SERVING; por isso o estado de cada endereço é acompanhado em uma conexão própria e o
+// resolver passa a anunciar apenas os endereços saudáveis, em ordem de preferência.
+type healthFailover struct {
+	resolver  *manual.Resolver
+	addresses []string
+	conns     []*grpc.ClientConn
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// Estado de cada endereço e o primeiro endereço anunciado ao resolver
+	mu        sync.Mutex
+	healthy   []bool
+	preferred string
+}
+
+// newHealthFailover cria as conexões de health check de cada endereço. Os endereços começam
+// saudáveis; o acompanhamento só começa em start, depois que o resolver estiver em uso.
+func newHealthFailover(r *manual.Resolver, addresses []string, dialOpts []grpc.DialOption) (*healthFailover, error) {
+	f := &healthFailover{
+		resolver:  r,
+		addresses: addresses,
+		healthy:   make([]bool, len(addresses)),
+		preferred: addresses[0],
+	}
+	for i, address := range addresses {
+		f.healthy[i] = true
+
+		conn, err := grpc.NewClient(address, dialOpts...)
+		if err != nil {
+			f.close()
+			return nil, err
+		}
+		f.conns = append(f.conns, conn)
+	}
+	return f, nil
+}
+
+// start passa a acompanhar o health check de todos os endereços
+func (f *healthFailover) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f.cancel = cancel
+
+	for i := range f.conns {
+		f.wg.Add(1)
+		go f.watch(ctx, i)
+	}
+}
+
+// watch acompanha o health check de um endereço até o contexto ser cancelado. Um servidor
+// que não implementa o serviço de health check é considerado saudável, como no gRPC.
+func (f *healthFailover) watch(ctx context.Context, i int) {
+	defer f.wg.Done()
+
+	client := healthpb.NewHealthClient(f.conns[i])
+	for {
+		err := f.watchOnce(ctx, client, i)
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			f.update(i, true)
+			return
+		}
+
+		// Servidor inacessível ou stream interrompido: o endereço deixa de ser usado até
+		// voltar a responder SERVING
+		f.update(i, false)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(healthRetryInterval):
+		}
+	}
+}
+
+// watchOnce acompanha o stream de health check de um endereço até ele ser interrompido
+func (f *healthFailover) watchOnce(ctx context.Context, client healthpb.HealthClient, i int) error {
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		f.update(i, resp.GetStatus() == healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+// update registra o estado de um endereço e anuncia ao resolver os endereços saudáveis.
+// Sem nenhum endereço saudável, todos são anunciados e o pick_first tenta cada um.
+func (f *healthFailover) update(i int, healthy bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.healthy[i] == healthy {
+		return
+	}
+	f.healthy[i] = healthy
+
+	var addresses []string
+	for j, address := range f.addresses {
+		if f.healthy[j] {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		addresses = f.addresses
+	}
+
+	// O pick_first mantém a conexão atual enquanto o endereço dela for anunciado. Quando o
+	// endereço preferido muda, ele é anunciado sozinho primeiro, para que a conexão passe a
+	// ele também quando um endereço de maior preferência volta a ficar saudável.
+	if addresses[0] != f.preferred {
+		f.preferred = addresses[0]
+		f.resolver.UpdateState(resolverState(addresses[:1]))
+	}
+	f.resolver.UpdateState(resolverState(addresses))
+}
+
+// close encerra o acompanhamento e fecha as conexões de health check
+func (f *healthFailover) close() {
+	if f.cancel != nil {
+		f.cancel()
+	}
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+	f.wg.Wait()
+}
+
+// resolverState retorna o estado do resolver com os endereços informados
+func resolverState(addresses []string) resolver.State {
+	state := resolver.State{}
+	for _, address := range addresses {
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: address})
+	}
+	return state
+}
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startHealthServer inicia um servidor TCP com o serviço v1 e o serviço de health check
+func startHealthServer(t *testing.T, server *fakeServer) (string, *health.Server) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	healthServer := health.NewServer()
+	notifications.RegisterNotificationsServiceServer(s, server)
+	healthpb.RegisterHealthServer(s, healthServer)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String(), healthServer
+}
+
+func TestPickFirstFailsOverOnNotServing(t *testing.T) {
+	primary, secondary := &fakeServer{}, &fakeServer{}
+	primaryAddr, primaryHealth := startHealthServer(t, primary)
+	secondaryAddr, _ := startHealthServer(t, secondary)
+
+	var mu sync.Mutex
+	var changes []string
+	c, err := NewClient(
+		WithServerAddresses(primaryAddr, secondaryAddr),
+		WithOrigin("test"),
+		WithRetryInterval(0),
+		WithTimeout(3*time.Second),
+		WithEndpointObserver(func(previous, current string) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, current)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	// Envia até a notificação chegar ao servidor esperado ou o prazo acabar
+	sendUntil := func(server *fakeServer) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			before := len(server.received())
+			if err := c.Notify(context.Background(), testData("p1")); err != nil {
+				t.Fatalf("Notify: %v", err)
+			}
+			if len(server.received()) > before {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("notificação não chegou ao servidor esperado; endpoint ativo %s", c.ActiveEndpoint())
+	}
+
+	sendUntil(primary)
+	if got := c.ActiveEndpoint(); got != primaryAddr {
+		t.Fatalf("ActiveEndpoint = %s, esperado %s", got, primaryAddr)
+	}
+
+	primaryHealth.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	sendUntil(secondary)
+	if got := c.ActiveEndpoint(); got != secondaryAddr {
+		t.Fatalf("ActiveEndpoint = %s, esperado %s após o failover", got, secondaryAddr)
+	}
+
+	// Com o primário saudável de novo, as chamadas voltam a ele
+	primaryHealth.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	sendUntil(primary)
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{primaryAddr, secondaryAddr, primaryAddr}
+	if len(changes) != len(want) {
+		t.Fatalf("EndpointObserver recebeu %v, esperado %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("EndpointObserver recebeu %v, esperado %v", changes, want)
+		}
+	}
+}
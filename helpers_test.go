@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"github.com/getsentry/sentry-go"
+	"google.golang.org/grpc"
+)
+
+// fakeServer implementa o serviço v1 em memória, registrando as requisições recebidas
+type fakeServer struct {
+	notifications.UnimplementedNotificationsServiceServer
+
+	mu       sync.Mutex
+	requests []*notifications.NotifyRequest
+	calls    atomic.Int32
+
+	// Executado a cada chamada de Notify, se definido; um erro é retornado ao cliente
+	notify func(ctx context.Context, req *notifications.NotifyRequest) error
+}
+
+func (s *fakeServer) Notify(ctx context.Context, req *notifications.NotifyRequest) (*notifications.NotifyResponse, error) {
+	s.calls.Add(1)
+	if s.notify != nil {
+		if err := s.notify(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	return &notifications.NotifyResponse{Id: "id-" + req.ProjectId}, nil
+}
+
+// received retorna as requisições recebidas até o momento
+func (s *fakeServer) received() []*notifications.NotifyRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*notifications.NotifyRequest(nil), s.requests...)
+}
+
+// newTestClient cria um cliente ligado ao servidor em processo, sem intervalo entre tentativas
+func newTestClient(t *testing.T, server notifications.NotificationsServiceServer, opts ...Option) *NotifyClient {
+	t.Helper()
+
+	opts = append([]Option{WithOrigin("test"), WithInProcessServer(server), WithRetryInterval(0)}, opts...)
+	c, err := NewClient(opts...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// serverListener define a rede e o endereço em que startServer escuta
+type serverListener struct {
+	network string
+	address string
+}
+
+// serverOption configura o servidor iniciado por startServer
+type serverOption func(*serverListener)
+
+// withUnixListener faz o servidor escutar no socket Unix informado, em vez de TCP
+func withUnixListener(path string) serverOption {
+	return func(l *serverListener) {
+		l.network = "unix"
+		l.address = path
+	}
+}
+
+// startServer inicia um servidor com o serviço v1, por padrão em uma porta TCP local, e retorna o endereço
+func startServer(t *testing.T, server notifications.NotificationsServiceServer, opts ...serverOption) string {
+	t.Helper()
+
+	listener := serverListener{network: "tcp", address: "127.0.0.1:0"}
+	for _, opt := range opts {
+		opt(&listener)
+	}
+
+	lis, err := net.Listen(listener.network, listener.address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	notifications.RegisterNotificationsServiceServer(s, server)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	return lis.Addr().String()
+}
+
+// testData retorna uma notificação válida do projeto informado
+func testData(projectID string) *Data {
+	return &Data{ProjectID: projectID, Scope: SYSTEM, Type: BOUNCE}
+}
+
+// captureSentry registra os erros enviados ao Sentry até o fim do teste e retorna uma função
+// que lista as mensagens registradas
+func captureSentry(t *testing.T) func() []string {
+	t.Helper()
+
+	var mu sync.Mutex
+	var messages []string
+	err := sentry.Init(sentry.ClientOptions{
+		BeforeSend: func(event *sentry.Event, hint *sentry.EventHint) *sentry.Event {
+			mu.Lock()
+			defer mu.Unlock()
+			if hint != nil && hint.OriginalException != nil {
+				messages = append(messages, hint.OriginalException.Error())
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sentry.Init(sentry.ClientOptions{}) })
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), messages...)
+	}
+}
@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// inProcessEndpoint identifica o transporte em processo em ActiveEndpoint e nas mensagens de erro
+const inProcessEndpoint = "in-process"
+
+// inProcessConn implementa grpc.ClientConnInterface chamando o servidor diretamente,
+// sem serialização em rede
+type inProcessConn struct {
+	server       notifications.NotificationsServiceServer
+	desc         *grpc.ServiceDesc
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+// newInProcessConn cria uma conexão em processo para o servidor informado
+func newInProcessConn(server notifications.NotificationsServiceServer, interceptors []grpc.UnaryClientInterceptor) *inProcessConn {
+	return &inProcessConn{
+		server:       server,
+		desc:         &notifications.NotificationsService_ServiceDesc,
+		interceptors: interceptors,
+	}
+}
+
+// Invoke executa a cadeia de interceptors e, ao final, o handler do servidor em processo.
+// Como não há conexão de rede, os interceptors recebem um *grpc.ClientConn nulo.
+func (c *inProcessConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	invoker := c.invoke
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+	return invoker(ctx, method, args, reply, nil, opts...)
+}
+
+// invoke executa o handler unário correspondente ao método no servidor em processo
+func (c *inProcessConn) invoke(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+	handler := c.unaryHandler(method)
+	if handler == nil {
+		return status.Errorf(codes.Unimplemented, "método %s não suportado pelo transporte em processo", method)
+	}
+
+	// Os metadados enviados pelo cliente chegam ao servidor como metadados recebidos
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	// Copia a requisição para que o servidor não compartilhe memória com o chamador
+	dec := func(in any) error {
+		proto.Merge(in.(proto.Message), args.(proto.Message))
+		return nil
+	}
+
+	out, err := handler(c.server, ctx, dec, nil)
+	if err != nil {
+		// Erros que não são status gRPC chegam ao cliente como Unknown, assim como na rede
+		if _, ok := status.FromError(err); !ok {
+			return status.Error(codes.Unknown, err.Error())
+		}
+		return err
+	}
+
+	proto.Merge(reply.(proto.Message), out.(proto.Message))
+	return nil
+}
+
+// NewStream não é suportado pelo transporte em processo
+func (c *inProcessConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "streaming não suportado pelo transporte em processo: %s", method)
+}
+
+// unaryHandler busca o handler do método na descrição do serviço
+func (c *inProcessConn) unaryHandler(method string) grpc.MethodHandler {
+	name, ok := strings.CutPrefix(method, "/"+c.desc.ServiceName+"/")
+	if !ok {
+		return nil
+	}
+	for _, m := range c.desc.Methods {
+		if m.MethodName == name {
+			return m.Handler
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// Command protocheck compara os arquivos .proto compilados com os descritores embutidos
+// no código gerado atual (file_*_proto_rawDesc) e falha se encontrar mudanças incompatíveis.
+//
+// Deve rodar antes de regenerar o código, enquanto pb/ ainda contém a versão anterior:
+//
+//	buf build -o bin/notifications.binpb
+//	go run ./internal/protocheck bin/notifications.binpb
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	// Registram os descritores do código gerado atual
+	_ "github.com/AdSeleto/notify/pb/notifications"
+	_ "github.com/AdSeleto/notify/pb/notifications/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func main() {
+	allowBreaking := flag.Bool("allow-breaking", false, "reporta as mudanças incompatíveis sem falhar")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Uso: protocheck [-allow-breaking] <imagem.binpb>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	files, err := loadImage(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "erro: %v\n", err)
+		os.Exit(1)
+	}
+
+	var changes []string
+	files.RangeFiles(func(next protoreflect.FileDescriptor) bool {
+		// Arquivos novos não têm versão anterior para comparar
+		prev, err := protoregistry.GlobalFiles.FindFileByPath(next.Path())
+		if err != nil {
+			return true
+		}
+		changes = append(changes, compareFiles(prev, next)...)
+		return true
+	})
+
+	if len(changes) == 0 {
+		fmt.Println("nenhuma mudança incompatível encontrada")
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "%d mudança(s) incompatível(is) em relação ao código gerado atual:\n", len(changes))
+	for _, change := range changes {
+		fmt.Fprintf(os.Stderr, "  - %s\n", change)
+	}
+	if !*allowBreaking {
+		os.Exit(1)
+	}
+}
+
+// loadImage lê um FileDescriptorSet (imagem do buf ou saída de protoc --descriptor_set_out --include_imports)
+func loadImage(path string) (*protoregistry.Files, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao ler imagem: %w", err)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(content, set); err != nil {
+		return nil, fmt.Errorf("imagem inválida %s: %w", path, err)
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("imagem inválida %s: %w", path, err)
+	}
+	return files, nil
+}
+
+// compareFiles lista as mudanças incompatíveis entre duas versões de um arquivo
+func compareFiles(prev, next protoreflect.FileDescriptor) []string {
+	var changes []string
+
+	if prev.Package() != next.Package() {
+		changes = append(changes, fmt.Sprintf("%s: pacote alterado de %s para %s", prev.Path(), prev.Package(), next.Package()))
+	}
+
+	changes = append(changes, compareMessages(prev.Messages(), next.Messages())...)
+	changes = append(changes, compareEnums(prev.Enums(), next.Enums())...)
+
+	services := prev.Services()
+	for i := 0; i < services.Len(); i++ {
+		prevService := services.Get(i)
+		nextService := next.Services().ByName(prevService.Name())
+		if nextService == nil {
+			changes = append(changes, fmt.Sprintf("serviço %s removido", prevService.FullName()))
+			continue
+		}
+		changes = append(changes, compareMethods(prevService, nextService)...)
+	}
+
+	return changes
+}
+
+// compareMessages compara as mensagens, inclusive as aninhadas, pelo nome
+func compareMessages(prev, next protoreflect.MessageDescriptors) []string {
+	var changes []string
+
+	for i := 0; i < prev.Len(); i++ {
+		prevMessage := prev.Get(i)
+		nextMessage := next.ByName(prevMessage.Name())
+		if nextMessage == nil {
+			changes = append(changes, fmt.Sprintf("mensagem %s removida", prevMessage.FullName()))
+			continue
+		}
+		changes = append(changes, compareFields(prevMessage, nextMessage)...)
+		changes = append(changes, compareMessages(prevMessage.Messages(), nextMessage.Messages())...)
+		changes = append(changes, compareEnums(prevMessage.Enums(), nextMessage.Enums())...)
+	}
+
+	return changes
+}
+
+// compareFields compara os campos de uma mensagem pelo número
+func compareFields(prev, next protoreflect.MessageDescriptor) []string {
+	var changes []string
+
+	fields := prev.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		prevField := fields.Get(i)
+		nextField := next.Fields().ByNumber(prevField.Number())
+
+		if nextField == nil {
+			if !next.ReservedRanges().Has(prevField.Number()) {
+				changes = append(changes, fmt.Sprintf("campo %s (%d) removido sem reservar o número", prevField.FullName(), prevField.Number()))
+			}
+			continue
+		}
+
+		if prevField.Name() != nextField.Name() {
+			changes = append(changes, fmt.Sprintf("campo %s (%d) renomeado para %s", prevField.FullName(), prevField.Number(), nextField.Name()))
+		}
+		if fieldType(prevField) != fieldType(nextField) {
+			changes = append(changes, fmt.Sprintf("campo %s (%d) mudou de tipo: %s para %s", prevField.FullName(), prevField.Number(), fieldType(prevField), fieldType(nextField)))
+		}
+	}
+
+	return changes
+}
+
+// fieldType descreve o tipo de um campo, incluindo cardinalidade e tipo referenciado
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	var t string
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s, %s>", fieldType(fd.MapKey()), fieldType(fd.MapValue()))
+	case fd.Message() != nil:
+		t = string(fd.Message().FullName())
+	case fd.Enum() != nil:
+		t = string(fd.Enum().FullName())
+	default:
+		t = fd.Kind().String()
+	}
+	if fd.IsList() {
+		return "repeated " + t
+	}
+	return t
+}
+
+// compareEnums compara os valores dos enums pelo número
+func compareEnums(prev, next protoreflect.EnumDescriptors) []string {
+	var changes []string
+
+	for i := 0; i < prev.Len(); i++ {
+		prevEnum := prev.Get(i)
+		nextEnum := next.ByName(prevEnum.Name())
+		if nextEnum == nil {
+			changes = append(changes, fmt.Sprintf("enum %s removido", prevEnum.FullName()))
+			continue
+		}
+
+		values := prevEnum.Values()
+		for j := 0; j < values.Len(); j++ {
+			prevValue := values.Get(j)
+			nextValue := nextEnum.Values().ByNumber(prevValue.Number())
+			switch {
+			case nextValue == nil && !nextEnum.ReservedRanges().Has(prevValue.Number()):
+				changes = append(changes, fmt.Sprintf("valor %s (%d) removido sem reservar o número", prevValue.FullName(), prevValue.Number()))
+			case nextValue != nil && nextValue.Name() != prevValue.Name():
+				changes = append(changes, fmt.Sprintf("valor %s (%d) renomeado para %s", prevValue.FullName(), prevValue.Number(), nextValue.Name()))
+			}
+		}
+	}
+
+	return changes
+}
+
+// compareMethods compara os métodos de um serviço pelo nome
+func compareMethods(prev, next protoreflect.ServiceDescriptor) []string {
+	var changes []string
+
+	methods := prev.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		prevMethod := methods.Get(i)
+		nextMethod := next.Methods().ByName(prevMethod.Name())
+		if nextMethod == nil {
+			changes = append(changes, fmt.Sprintf("método %s removido", prevMethod.FullName()))
+			continue
+		}
+
+		if prevMethod.Input().FullName() != nextMethod.Input().FullName() {
+			changes = append(changes, fmt.Sprintf("método %s mudou a requisição de %s para %s", prevMethod.FullName(), prevMethod.Input().FullName(), nextMethod.Input().FullName()))
+		}
+		if prevMethod.Output().FullName() != nextMethod.Output().FullName() {
+			changes = append(changes, fmt.Sprintf("método %s mudou a resposta de %s para %s", prevMethod.FullName(), prevMethod.Output().FullName(), nextMethod.Output().FullName()))
+		}
+		if prevMethod.IsStreamingClient() != nextMethod.IsStreamingClient() || prevMethod.IsStreamingServer() != nextMethod.IsStreamingServer() {
+			changes = append(changes, fmt.Sprintf("método %s mudou o tipo de streaming", prevMethod.FullName()))
+		}
+	}
+
+	return changes
+}
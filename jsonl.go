@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Tamanho máximo de uma linha JSON Lines
+const maxJSONLLineSize = 1024 * 1024
+
+// Line é uma notificação lida de um arquivo JSON Lines
+type Line struct {
+	// Número da linha no arquivo, a partir de 1
+	Number int
+
+	// Notificação decodificada; nil se a linha não puder ser decodificada
+	Data *Data
+
+	// Erro de decodificação ou de validação da linha
+	Err error
+}
+
+// JSONLReader lê notificações no formato JSON Lines, uma Data por linha.
+// Linhas em branco são ignoradas.
+type JSONLReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewJSONLReader cria um leitor de notificações JSON Lines
+func NewJSONLReader(r io.Reader) *JSONLReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineSize)
+	return &JSONLReader{scanner: scanner}
+}
+
+// Next retorna a próxima linha. Erros de decodificação e de validação são reportados
+// em Line.Err, para que a leitura continue; o erro retornado é io.EOF ao final do
+// arquivo ou um erro de leitura.
+func (r *JSONLReader) Next() (*Line, error) {
+	for r.scanner.Scan() {
+		r.line++
+		content := bytes.TrimSpace(r.scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+
+		line := &Line{Number: r.line}
+		data := &Data{}
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(data); err != nil {
+			line.Err = fmt.Errorf("JSON inválido: %w", err)
+			return line, nil
+		}
+
+		line.Data = data
+		line.Err = data.Validate()
+		return line, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("falha ao ler linha %d: %w", r.line+1, err)
+	}
+	return nil, io.EOF
+}
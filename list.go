@@ -0,0 +1,170 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Notification representa uma notificação armazenada no serviço
+type Notification struct {
+	ID        string            `json:"id"`
+	ProjectID string            `json:"project_id"`
+	Scope     string            `json:"scope"`
+	Type      string            `json:"type"`
+	Origin    string            `json:"origin"`
+	Metadata  map[string]string `json:"metadata"`
+	Read      bool              `json:"read"`
+	CreatedAt time.Time         `json:"created_at"`
+
+	// Momento em que o evento aconteceu; vazio para notificações enviadas sem OccurredAt
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ListFilter define os filtros da listagem de notificações. Campos vazios não restringem o resultado.
+type ListFilter struct {
+	ProjectID string
+	Scope     string
+	Type      string
+	Origin    string
+
+	// Quando definido, retorna apenas notificações lidas (true) ou não lidas (false)
+	Read *bool
+
+	// Intervalo de criação das notificações
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Quantidade de notificações por página; zero usa o padrão do servidor
+	PageSize int
+
+	// Cursor da página a buscar, retornado em Page.NextCursor; vazio para a primeira página
+	Cursor string
+}
+
+// Page é uma página da listagem de notificações
+type Page struct {
+	Notifications []*Notification
+
+	// Cursor da próxima página; vazio quando não há mais páginas
+	NextCursor string
+}
+
+// List retorna um iterador sobre todas as notificações que atendem ao filtro,
+// buscando as páginas sob demanda. Em caso de erro, o iterador retorna o erro e termina.
+//
+//	for n, err := range notifier.List(ctx, notify.ListFilter{ProjectID: "seu-projeto-id"}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(n.ID, n.Type)
+//	}
+func (c *NotifyClient) List(ctx context.Context, filter ListFilter) iter.Seq2[*Notification, error] {
+	return func(yield func(*Notification, error) bool) {
+		for {
+			page, err := c.ListPage(ctx, filter)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, n := range page.Notifications {
+				if !yield(n, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			filter.Cursor = page.NextCursor
+		}
+	}
+}
+
+// ListPage busca uma única página de notificações, para quem precisa controlar a paginação
+func (c *NotifyClient) ListPage(ctx context.Context, filter ListFilter) (*Page, error) {
+	req, err := filter.toGRPCRequest()
+	if err != nil {
+		return nil, fmt.Errorf("filtro inválido: %w", err)
+	}
+
+	state := c.state.Load()
+
+	var resp *notifications.ListNotificationsResponse
+	_, err = c.call(ctx, state, "listar notificações", func(ctx context.Context, p *peer.Peer) error {
+		var err error
+		resp, err = state.client.ListNotifications(ctx, req, state.options.callOptions(req, p)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{
+		Notifications: make([]*Notification, 0, len(resp.GetNotifications())),
+		NextCursor:    resp.GetNextCursor(),
+	}
+	for _, n := range resp.GetNotifications() {
+		page.Notifications = append(page.Notifications, newNotification(n))
+	}
+	return page, nil
+}
+
+// Converte o filtro para uma request gRPC, validando scope e type quando informados
+func (f ListFilter) toGRPCRequest() (*notifications.ListNotificationsRequest, error) {
+	if f.Scope != "" && !slices.Contains(Scopes(), f.Scope) {
+		return nil, fmt.Errorf("invalid scope: %s. Use one of the constants: %s", f.Scope, strings.Join(Scopes(), ", "))
+	}
+	if f.Type != "" && !slices.Contains(Types(), f.Type) {
+		return nil, fmt.Errorf("invalid type: %s. Use one of the constants: %s", f.Type, strings.Join(Types(), ", "))
+	}
+	if f.PageSize < 0 {
+		return nil, fmt.Errorf("o tamanho da página não pode ser negativo")
+	}
+	if !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero() && f.CreatedBefore.Before(f.CreatedAfter) {
+		return nil, fmt.Errorf("CreatedBefore deve ser posterior a CreatedAfter")
+	}
+
+	req := &notifications.ListNotificationsRequest{
+		ProjectId: f.ProjectID,
+		Scope:     f.Scope,
+		Type:      f.Type,
+		Origin:    f.Origin,
+		Read:      f.Read,
+		PageSize:  int32(f.PageSize),
+		Cursor:    f.Cursor,
+	}
+	if !f.CreatedAfter.IsZero() {
+		req.CreatedAfter = timestamppb.New(f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		req.CreatedBefore = timestamppb.New(f.CreatedBefore)
+	}
+	return req, nil
+}
+
+// newNotification converte uma notificação gRPC para o tipo da biblioteca
+func newNotification(n *notifications.Notification) *Notification {
+	notification := &Notification{
+		ID:        n.GetId(),
+		ProjectID: n.GetProjectId(),
+		Scope:     n.GetScope(),
+		Type:      n.GetType(),
+		Origin:    n.GetOrigin(),
+		Metadata:  n.GetMetadata(),
+		Read:      n.GetRead(),
+	}
+	if n.GetCreatedAt() != nil {
+		notification.CreatedAt = n.GetCreatedAt().AsTime()
+	}
+	if n.GetOccurredAt() != nil {
+		notification.OccurredAt = n.GetOccurredAt().AsTime()
+	}
+	return notification
+}
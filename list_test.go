@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// listServer pagina uma lista fixa de notificações, usando o índice da próxima como cursor
+type listServer struct {
+	fakeServer
+
+	total int
+
+	// Página a partir da qual o servidor retorna erro; zero para nunca falhar
+	failAt int
+
+	mu      sync.Mutex
+	cursors []string
+}
+
+func (s *listServer) ListNotifications(ctx context.Context, req *notifications.ListNotificationsRequest) (*notifications.ListNotificationsResponse, error) {
+	s.mu.Lock()
+	s.cursors = append(s.cursors, req.GetCursor())
+	page := len(s.cursors)
+	s.mu.Unlock()
+
+	if s.failAt > 0 && page >= s.failAt {
+		return nil, status.Error(codes.PermissionDenied, "acesso negado")
+	}
+
+	start := 0
+	if req.GetCursor() != "" {
+		start, _ = strconv.Atoi(req.GetCursor())
+	}
+	end := min(start+int(req.GetPageSize()), s.total)
+
+	resp := &notifications.ListNotificationsResponse{}
+	for i := start; i < end; i++ {
+		resp.Notifications = append(resp.Notifications, &notifications.Notification{Id: fmt.Sprint("n", i), ProjectId: req.GetProjectId()})
+	}
+	if end < s.total {
+		resp.NextCursor = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+// requestedCursors retorna os cursores recebidos, um por página buscada
+func (s *listServer) requestedCursors() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.cursors)
+}
+
+func TestListPagination(t *testing.T) {
+	server := &listServer{total: 7}
+	c := newTestClient(t, server)
+
+	var ids []string
+	for n, err := range c.List(context.Background(), ListFilter{ProjectID: "p1", PageSize: 3}) {
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if n.ProjectID != "p1" {
+			t.Errorf("ProjectID = %q, esperado p1", n.ProjectID)
+		}
+		ids = append(ids, n.ID)
+	}
+
+	if want := []string{"n0", "n1", "n2", "n3", "n4", "n5", "n6"}; !slices.Equal(ids, want) {
+		t.Errorf("notificações %v, esperado %v", ids, want)
+	}
+	if cursors := server.requestedCursors(); !slices.Equal(cursors, []string{"", "3", "6"}) {
+		t.Errorf("cursores %v, esperado [ 3 6]", cursors)
+	}
+}
+
+func TestListStopsEarly(t *testing.T) {
+	server := &listServer{total: 10}
+	c := newTestClient(t, server)
+
+	// Interromper o laço na primeira página não busca as seguintes
+	count := 0
+	for _, err := range c.List(context.Background(), ListFilter{PageSize: 3}) {
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	if cursors := server.requestedCursors(); len(cursors) != 1 {
+		t.Errorf("%d páginas buscadas, esperado 1", len(cursors))
+	}
+}
+
+func TestListErrorMidway(t *testing.T) {
+	server := &listServer{total: 10, failAt: 2}
+	c := newTestClient(t, server, WithMaxRetries(0))
+
+	// As notificações da primeira página são entregues, e o erro da segunda encerra o iterador
+	var ids []string
+	var errs []error
+	for n, err := range c.List(context.Background(), ListFilter{PageSize: 3}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, n.ID)
+	}
+
+	if !slices.Equal(ids, []string{"n0", "n1", "n2"}) {
+		t.Errorf("notificações %v, esperado a primeira página", ids)
+	}
+	if len(errs) != 1 || status.Code(errs[0]) != codes.PermissionDenied {
+		t.Errorf("erros %v, esperado um único PermissionDenied", errs)
+	}
+	if cursors := server.requestedCursors(); !slices.Equal(cursors, []string{"", "3"}) {
+		t.Errorf("cursores %v, esperado [ 3]", cursors)
+	}
+
+	// Um filtro inválido é retornado como erro sem chamar o servidor
+	for _, err := range c.List(context.Background(), ListFilter{Scope: "OUTRO"}) {
+		if err == nil {
+			t.Error("filtro inválido aceito")
+		}
+	}
+	if cursors := server.requestedCursors(); len(cursors) != 2 {
+		t.Errorf("%d páginas buscadas após o filtro inválido, esperado 2", len(cursors))
+	}
+}
@@ -0,0 +1,221 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Handler envia uma notificação e retorna o resultado
+type Handler func(ctx context.Context, params *Data) (*Result, error)
+
+// Middleware envolve um Handler para executar lógica antes e depois do envio, como filtros,
+// reescrita de metadados, amostragem ou auditoria. Para descartar a notificação sem erro,
+// basta retornar sem chamar next; o chamador recebe um Result vazio.
+type Middleware func(next Handler) Handler
+
+// handle envia a notificação pela cadeia de middlewares configurada
+func (c *NotifyClient) handle(ctx context.Context, state *clientState, params *Data) (*Result, error) {
+	h := func(ctx context.Context, params *Data) (*Result, error) {
+		return c.deliver(ctx, state, params)
+	}
+
+	// O primeiro middleware da cadeia é o mais externo
+	middlewares := state.options.chain()
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+
+	// Enrichers de WithEnrichers, usados por EnrichmentMiddleware sem enrichers próprios
+	if len(state.options.Enrichers) > 0 {
+		ctx = context.WithValue(ctx, enrichersKey{}, state.options.Enrichers)
+	}
+
+	result, err := h(ctx, params)
+	if result == nil && err == nil {
+		// Notificação descartada por um middleware
+		return &Result{}, nil
+	}
+	return result, err
+}
+
+// chain retorna a cadeia de middlewares de cada envio. Por padrão, a notificação é validada e
+// passa pelos enrichers de WithEnrichers antes dos middlewares de WithMiddleware; com
+// WithMiddlewareChain, os middlewares informados substituem esses passos.
+func (o *ClientOptions) chain() []Middleware {
+	if o.CustomChain {
+		return o.Middlewares
+	}
+	return append([]Middleware{ValidationMiddleware(), EnrichmentMiddleware()}, o.Middlewares...)
+}
+
+// enrichersKey guarda no contexto de um envio os enrichers configurados no cliente
+type enrichersKey struct{}
+
+// ValidationMiddleware valida a notificação nesse ponto da cadeia. É o primeiro passo da cadeia
+// padrão; com WithMiddlewareChain, permite que middlewares anteriores preencham ou corrijam
+// campos antes da validação e que os seguintes só recebam notificações válidas (ex.: antes de
+// consumir o limite de envios).
+func ValidationMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, params *Data) (*Result, error) {
+			if err := params.Validate(); err != nil {
+				return nil, fmt.Errorf("parâmetros inválidos: %w", err)
+			}
+			return next(ctx, params)
+		}
+	}
+}
+
+// EnrichmentMiddleware executa os enrichers nesse ponto da cadeia, com as mesmas regras de
+// WithEnrichers: chaves já presentes nos metadados não são sobrescritas. Sem enrichers, executa
+// os configurados com WithEnrichers, como no segundo passo da cadeia padrão.
+func EnrichmentMiddleware(enrichers ...Enricher) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, params *Data) (*Result, error) {
+			enrichers := enrichers
+			if len(enrichers) == 0 {
+				enrichers, _ = ctx.Value(enrichersKey{}).([]Enricher)
+			}
+			return next(ctx, enrich(ctx, params, enrichers))
+		}
+	}
+}
+
+// DedupMiddleware evita reenviar notificações com a mesma DedupKey dentro da janela informada,
+// retornando o resultado do primeiro envio, com zero tentativas. Envios simultâneos com a mesma
+// chave aguardam o primeiro terminar, em vez de chegarem todos ao servidor. Notificações sem
+// DedupKey e envios que falharam não são deduplicados. A deduplicação vale apenas para o processo atual.
+func DedupMiddleware(window time.Duration) Middleware {
+	var mu sync.Mutex
+	type entry struct {
+		// Fechado quando o primeiro envio termina
+		done chan struct{}
+
+		// Resultado do primeiro envio; nil enquanto ele está em andamento ou se falhou
+		result *Result
+		sentAt time.Time
+	}
+	sent := make(map[string]*entry)
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, params *Data) (*Result, error) {
+			if params.DedupKey == "" {
+				return next(ctx, params)
+			}
+
+			for {
+				mu.Lock()
+				now := time.Now()
+				for key, e := range sent {
+					if e.result != nil && now.Sub(e.sentAt) >= window {
+						delete(sent, key)
+					}
+				}
+				e, ok := sent[params.DedupKey]
+				if !ok {
+					// Primeiro envio com a chave: registra o envio em andamento antes de chamar next
+					e = &entry{done: make(chan struct{})}
+					sent[params.DedupKey] = e
+					mu.Unlock()
+
+					return func() (result *Result, err error) {
+						// Também em um panic, para que os envios que aguardam não fiquem presos
+						defer func() {
+							mu.Lock()
+							if err == nil && result != nil {
+								e.result, e.sentAt = result, time.Now()
+							} else {
+								delete(sent, params.DedupKey)
+							}
+							mu.Unlock()
+							close(e.done)
+						}()
+						return next(ctx, params)
+					}()
+				}
+				mu.Unlock()
+
+				select {
+				case <-e.done:
+				case <-ctx.Done():
+					return nil, fmt.Errorf("cancelado enquanto aguardava o envio com a mesma chave de deduplicação: %w", ctx.Err())
+				}
+				if e.result != nil {
+					result := *e.result
+					result.Attempts = 0
+					return &result, nil
+				}
+				// O primeiro envio falhou: tenta novamente, possivelmente como o novo primeiro envio
+			}
+		}
+	}
+}
+
+// RateLimitMiddleware limita os envios a perSecond por segundo, permitindo rajadas de até burst
+// envios. Acima do limite, a chamada aguarda sua vez ou o cancelamento do contexto.
+// Com perSecond zero ou negativo, não há limite.
+func RateLimitMiddleware(perSecond float64, burst int) Middleware {
+	if perSecond <= 0 {
+		return func(next Handler) Handler {
+			return next
+		}
+	}
+
+	bucket := &tokenBucket{
+		rate:   perSecond,
+		burst:  float64(max(burst, 1)),
+		tokens: float64(max(burst, 1)),
+		last:   time.Now(),
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, params *Data) (*Result, error) {
+			if wait := bucket.reserve(); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					bucket.cancel()
+					return nil, fmt.Errorf("cancelado enquanto aguardava o limite de envios: %w", ctx.Err())
+				case <-timer.C:
+				}
+			}
+			return next(ctx, params)
+		}
+	}
+}
+
+// tokenBucket implementa o limite de RateLimitMiddleware
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// reserve consome um token e retorna quanto tempo esperar até que ele esteja disponível
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel devolve o token de uma reserva que não foi usada
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+)
+
+// fillScope é um middleware que preenche o escopo ausente
+func fillScope(next Handler) Handler {
+	return func(ctx context.Context, params *Data) (*Result, error) {
+		if params.Scope == "" {
+			params = params.clone()
+			params.Scope = SYSTEM
+		}
+		return next(ctx, params)
+	}
+}
+
+func TestDefaultChainValidatesBeforeMiddlewares(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, WithMiddleware(fillScope))
+
+	_, err := c.NotifyWithResult(context.Background(), &Data{ProjectID: "p1", Type: BOUNCE})
+	if err == nil {
+		t.Fatal("esperado erro de validação antes dos middlewares de WithMiddleware")
+	}
+	if calls := server.calls.Load(); calls != 0 {
+		t.Fatalf("servidor recebeu %d chamadas, esperado 0", calls)
+	}
+}
+
+func TestMiddlewareChainReplacesDefaultSteps(t *testing.T) {
+	server := &fakeServer{}
+	var sampled map[string]string
+	sample := func(next Handler) Handler {
+		return func(ctx context.Context, params *Data) (*Result, error) {
+			sampled = params.Metadata
+			return next(ctx, params)
+		}
+	}
+	c := newTestClient(t, server,
+		WithEnrichers(EnvironmentEnricher("test")),
+		WithMiddlewareChain(fillScope, sample, ValidationMiddleware(), EnrichmentMiddleware()),
+	)
+
+	if _, err := c.NotifyWithResult(context.Background(), &Data{ProjectID: "p1", Type: BOUNCE}); err != nil {
+		t.Fatalf("NotifyWithResult: %v", err)
+	}
+	if _, ok := sampled[MetadataEnvironment]; ok {
+		t.Error("enrichers executados antes da amostragem")
+	}
+
+	received := server.received()
+	if len(received) != 1 {
+		t.Fatalf("servidor recebeu %d notificações, esperado 1", len(received))
+	}
+	if got := received[0].Metadata[MetadataEnvironment]; got != "test" {
+		t.Errorf("metadado %s = %q, esperado %q", MetadataEnvironment, got, "test")
+	}
+}
+
+func TestMiddlewareChainInBatch(t *testing.T) {
+	server := &fakeServer{}
+	c := newTestClient(t, server, WithMiddlewareChain(fillScope, ValidationMiddleware()))
+
+	items := []*Data{{ProjectID: "p1", Type: BOUNCE}, {ProjectID: "p2", Type: BOUNCE}}
+	if _, err := c.NotifyBatch(context.Background(), items); err != nil {
+		t.Fatalf("NotifyBatch: %v", err)
+	}
+	if got := len(server.received()); got != 2 {
+		t.Fatalf("servidor recebeu %d notificações, esperado 2", got)
+	}
+}
+
+func TestDedupMiddlewareConcurrentSends(t *testing.T) {
+	release := make(chan struct{})
+	server := &fakeServer{notify: func(ctx context.Context, req *notifications.NotifyRequest) error {
+		<-release
+		return nil
+	}}
+	c := newTestClient(t, server, WithMiddleware(DedupMiddleware(time.Minute)))
+
+	const senders = 8
+	results := make([]*Result, senders)
+	errs := make([]error, senders)
+	var wg sync.WaitGroup
+	for i := range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			params := testData("p1")
+			params.DedupKey = "order-1"
+			results[i], errs[i] = c.NotifyWithResult(context.Background(), params)
+		}()
+	}
+
+	// Dá tempo para todos os envios chegarem ao middleware antes de liberar o servidor
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := len(server.received()); got != 1 {
+		t.Fatalf("servidor recebeu %d notificações, esperado 1", got)
+	}
+	first := 0
+	for i := range senders {
+		if errs[i] != nil {
+			t.Fatalf("envio %d: %v", i, errs[i])
+		}
+		if results[i].ID != "id-p1" {
+			t.Errorf("envio %d: ID = %q, esperado %q", i, results[i].ID, "id-p1")
+		}
+		if results[i].Attempts > 0 {
+			first++
+		}
+	}
+	if first != 1 {
+		t.Errorf("%d envios com tentativas, esperado apenas o primeiro", first)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := &tokenBucket{rate: 10, burst: 2, tokens: 2, last: time.Now()}
+
+	// A rajada é atendida sem espera
+	for i := range 2 {
+		if wait := bucket.reserve(); wait != 0 {
+			t.Fatalf("reserva %d: espera de %s, esperado 0", i, wait)
+		}
+	}
+
+	// Sem tokens, cada reserva espera um intervalo a mais
+	first := bucket.reserve()
+	second := bucket.reserve()
+	if first < 90*time.Millisecond || first > 100*time.Millisecond {
+		t.Errorf("terceira reserva: espera de %s, esperado ~100ms", first)
+	}
+	if second < 190*time.Millisecond || second > 200*time.Millisecond {
+		t.Errorf("quarta reserva: espera de %s, esperado ~200ms", second)
+	}
+
+	// Reservas canceladas devolvem o token
+	bucket.cancel()
+	bucket.cancel()
+	if wait := bucket.reserve(); wait > 100*time.Millisecond {
+		t.Errorf("reserva após cancelamentos: espera de %s, esperado até 100ms", wait)
+	}
+
+	// Os tokens se acumulam com o tempo, até burst
+	bucket.last = bucket.last.Add(-time.Hour)
+	if wait := bucket.reserve(); wait != 0 {
+		t.Errorf("reserva após uma hora: espera de %s, esperado 0", wait)
+	}
+	if bucket.tokens != bucket.burst-1 {
+		t.Errorf("tokens = %v, esperado %v", bucket.tokens, bucket.burst-1)
+	}
+}
+
+func TestRateLimitMiddlewareCanceled(t *testing.T) {
+	c := newTestClient(t, &fakeServer{}, WithMiddleware(RateLimitMiddleware(0.001, 1)))
+
+	if err := c.Notify(context.Background(), testData("p1")); err != nil {
+		t.Fatalf("primeiro envio: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Notify(ctx, testData("p1")); err == nil {
+		t.Fatal("esperado erro de cancelamento enquanto aguarda o limite")
+	}
+}
@@ -1,9 +1,17 @@
 package notify
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/AdSeleto/notify/pb/notifications"
+	notificationsv2 "github.com/AdSeleto/notify/pb/notifications/v2"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Constantes para Scope
@@ -30,40 +38,194 @@ const (
 	SPAM_COMPLAINTS     = "SPAM_COMPLAINTS"
 )
 
+// Constantes para Severity, da menos para a mais urgente
+const (
+	INFO     = "INFO"
+	WARNING  = "WARNING"
+	CRITICAL = "CRITICAL"
+)
+
+// Severidade padrão de cada tipo de notificação
+var defaultSeverities = map[string]string{
+	BLACKLIST:           CRITICAL,
+	HIGH_BOUNCE:         WARNING,
+	DELIVERABILITY_DROP: WARNING,
+	COMPLETED:           INFO,
+	FAILED:              CRITICAL,
+	ISSUES:              WARNING,
+	IMPORT_COMPLETED:    INFO,
+	STATE_CHANGE:        INFO,
+	DAILY_SUMMARY:       INFO,
+	PAUSED:              WARNING,
+	BOUNCE:              WARNING,
+	SPAM_COMPLAINTS:     CRITICAL,
+}
+
 // Data representa os parâmetros para criar uma notificação
 type Data struct {
 	ProjectID string            `json:"project_id"`
 	Scope     string            `json:"scope"`
 	Type      string            `json:"type"`
 	Metadata  map[string]string `json:"metadata"`
+
+	// Metadados com números, booleanos, listas ou objetos aninhados. São enviados sem
+	// conversão a servidores v2; para servidores v1, os valores que não são strings são
+	// codificados em JSON. As chaves não podem repetir as de Metadata.
+	StructuredMetadata map[string]any `json:"structured_metadata"`
+
+	// Chave de deduplicação: notificações com a mesma chave são criadas uma única vez.
+	// Apenas servidores v2 a utilizam.
+	DedupKey string `json:"dedup_key"`
+
+	// Urgência da notificação (INFO, WARNING ou CRITICAL); vazio usa DefaultSeverity(Type)
+	Severity string `json:"severity"`
+
+	// Momento em que o evento aconteceu. Se vazio, é preenchido com o momento da chamada de
+	// Notify, NotifyAsync ou NotifyBatch e mantido nas retentativas e na fila assíncrona.
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Result representa uma notificação criada pelo servidor
+type Result struct {
+	// Identificador da notificação, usado em Read
+	ID string `json:"id"`
+
+	// Data de criação registrada pelo servidor
+	CreatedAt time.Time `json:"created_at"`
+
+	// Número de tentativas usadas no envio
+	Attempts int `json:"attempts"`
+}
+
+// notifyResponse é a resposta de Notify, comum às versões do serviço
+type notifyResponse interface {
+	GetId() string
+	GetCreatedAt() *timestamppb.Timestamp
+}
+
+// newResult converte a resposta do servidor em um Result
+func newResult(resp notifyResponse, attempts int) *Result {
+	result := &Result{
+		ID:       resp.GetId(),
+		Attempts: attempts,
+	}
+	if resp.GetCreatedAt() != nil {
+		result.CreatedAt = resp.GetCreatedAt().AsTime()
+	}
+	return result
+}
+
+// Scopes retorna os escopos permitidos
+func Scopes() []string {
+	return []string{CAMPAIGN, PROJECT, SYSTEM, WARMUP}
+}
+
+// Types retorna os tipos de notificação permitidos
+func Types() []string {
+	return []string{BLACKLIST, HIGH_BOUNCE, DELIVERABILITY_DROP, COMPLETED, FAILED, ISSUES, IMPORT_COMPLETED, STATE_CHANGE, DAILY_SUMMARY, PAUSED, BOUNCE, SPAM_COMPLAINTS}
+}
+
+// Severities retorna as severidades permitidas, da menos para a mais urgente
+func Severities() []string {
+	return []string{INFO, WARNING, CRITICAL}
+}
+
+// DefaultSeverity retorna a severidade usada para o tipo quando Data.Severity não é informada
+func DefaultSeverity(typ string) string {
+	if severity, ok := defaultSeverities[typ]; ok {
+		return severity
+	}
+	return INFO
+}
+
+// clone retorna uma cópia dos parâmetros que não compartilha os mapas de metadados
+func (np *Data) clone() *Data {
+	c := *np
+	c.Metadata = maps.Clone(np.Metadata)
+	c.StructuredMetadata = maps.Clone(np.StructuredMetadata)
+	return &c
+}
+
+// stamp retorna os parâmetros com OccurredAt preenchido, sem alterar os do chamador
+func (np *Data) stamp(now time.Time) *Data {
+	if !np.OccurredAt.IsZero() {
+		return np
+	}
+	c := np.clone()
+	c.OccurredAt = now
+	return c
+}
+
+// occurredAt converte OccurredAt para o formato gRPC, ou nil se não estiver preenchido
+func (np *Data) occurredAt() *timestamppb.Timestamp {
+	if np.OccurredAt.IsZero() {
+		return nil
+	}
+	return timestamppb.New(np.OccurredAt)
+}
+
+// severity retorna a severidade informada ou, se vazia, a padrão do tipo
+func (np *Data) severity() string {
+	if np.Severity != "" {
+		return np.Severity
+	}
+	return DefaultSeverity(np.Type)
 }
 
 // Valida se o scope está entre os valores permitidos
 func (np *Data) validateScope() error {
-	switch np.Scope {
-	case CAMPAIGN, PROJECT, SYSTEM, WARMUP:
-		return nil
-	default:
-		return fmt.Errorf("invalid scope: %s. Use one of the constants: CAMPAIGN, PROJECT, SYSTEM, WARMUP", np.Scope)
+	if !slices.Contains(Scopes(), np.Scope) {
+		return fmt.Errorf("invalid scope: %s. Use one of the constants: %s", np.Scope, strings.Join(Scopes(), ", "))
 	}
+	return nil
 }
 
 // Valida se o type está entre os valores permitidos
 func (np *Data) validateType() error {
-	switch np.Type {
-	case BLACKLIST, HIGH_BOUNCE, DELIVERABILITY_DROP, COMPLETED, FAILED, ISSUES, IMPORT_COMPLETED, STATE_CHANGE, DAILY_SUMMARY, PAUSED, BOUNCE, SPAM_COMPLAINTS:
-		return nil
-	default:
-		return fmt.Errorf("invalid type: %s. Use one of the constants: BLACKLIST, HIGH_BOUNCE, DELIVERABILITY_DROP, COMPLETED, FAILED, ISSUES, IMPORT_COMPLETED, STATE_CHANGE, DAILY_SUMMARY, PAUSED, BOUNCE, SPAM_COMPLAINTS", np.Type)
+	if !slices.Contains(Types(), np.Type) {
+		return fmt.Errorf("invalid type: %s. Use one of the constants: %s", np.Type, strings.Join(Types(), ", "))
 	}
+	return nil
 }
 
-// Converte os parâmetros de notificação para uma request gRPC
-func (np *Data) toGRPCRequest(origin string) (*notifications.NotifyRequest, error) {
+// Valida se a severidade, quando informada, está entre os valores permitidos
+func (np *Data) validateSeverity() error {
+	if np.Severity != "" && !slices.Contains(Severities(), np.Severity) {
+		return fmt.Errorf("invalid severity: %s. Use one of the constants: %s", np.Severity, strings.Join(Severities(), ", "))
+	}
+	return nil
+}
+
+// Valida se os metadados estruturados podem ser enviados e não repetem chaves de Metadata
+func (np *Data) validateStructuredMetadata() error {
+	for k, v := range np.StructuredMetadata {
+		if _, ok := np.Metadata[k]; ok {
+			return fmt.Errorf("metadata key %s is set in both Metadata and StructuredMetadata", k)
+		}
+		if _, err := structpb.NewValue(v); err != nil {
+			return fmt.Errorf("invalid structured metadata %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// Validate verifica se scope, type e severity estão entre os valores permitidos e se os metadados podem ser enviados
+func (np *Data) Validate() error {
 	if err := np.validateScope(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := np.validateType(); err != nil {
+		return err
+	}
+	if err := np.validateSeverity(); err != nil {
+		return err
+	}
+	return np.validateStructuredMetadata()
+}
+
+// Converte os parâmetros de notificação para uma request gRPC
+func (np *Data) toGRPCRequest(origin string) (*notifications.NotifyRequest, error) {
+	if err := np.Validate(); err != nil {
 		return nil, err
 	}
 
@@ -72,11 +234,60 @@ func (np *Data) toGRPCRequest(origin string) (*notifications.NotifyRequest, erro
 		np.Metadata = make(map[string]string)
 	}
 
+	// O v1 só aceita strings: os metadados estruturados são codificados em JSON
+	metadata := np.Metadata
+	if len(np.StructuredMetadata) > 0 {
+		metadata = maps.Clone(np.Metadata)
+		for k, v := range np.StructuredMetadata {
+			if str, ok := v.(string); ok {
+				metadata[k] = str
+				continue
+			}
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid structured metadata %s: %w", k, err)
+			}
+			metadata[k] = string(encoded)
+		}
+	}
+
 	return &notifications.NotifyRequest{
-		ProjectId: np.ProjectID,
-		Scope:     np.Scope,
-		Type:      np.Type,
-		Origin:    origin,
-		Metadata:  np.Metadata,
+		ProjectId:  np.ProjectID,
+		Scope:      np.Scope,
+		Type:       np.Type,
+		Origin:     origin,
+		Metadata:   metadata,
+		Severity:   np.severity(),
+		OccurredAt: np.occurredAt(),
+	}, nil
+}
+
+// Converte os parâmetros de notificação para uma request gRPC do serviço v2
+func (np *Data) toGRPCRequestV2(origin string) (*notificationsv2.NotifyRequest, error) {
+	if err := np.Validate(); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]*structpb.Value, len(np.Metadata)+len(np.StructuredMetadata))
+	for k, v := range np.Metadata {
+		fields[k] = structpb.NewStringValue(v)
+	}
+	for k, v := range np.StructuredMetadata {
+		value, err := structpb.NewValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid structured metadata %s: %w", k, err)
+		}
+		fields[k] = value
+	}
+
+	return &notificationsv2.NotifyRequest{
+		ProjectId:  np.ProjectID,
+		Scope:      notificationsv2.Scope(notificationsv2.Scope_value["SCOPE_"+np.Scope]),
+		Type:       notificationsv2.Type(notificationsv2.Type_value["TYPE_"+np.Type]),
+		Origin:     origin,
+		Metadata:   &structpb.Struct{Fields: fields},
+		Severity:   notificationsv2.Severity(notificationsv2.Severity_value["SEVERITY_"+np.severity()]),
+		DedupKey:   np.DedupKey,
+		OccurredAt: np.occurredAt(),
 	}, nil
 }
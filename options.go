@@ -1,7 +1,28 @@
 package notify
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrEventTooOld é retornado quando uma notificação é mais antiga que MaxEventAge
+var ErrEventTooOld = errors.New("notificação mais antiga que a idade máxima permitida")
+
+// Constantes para LoadBalancing
+const (
+	PICK_FIRST  = "pick_first"
+	ROUND_ROBIN = "round_robin"
 )
 
 // ClientOptions contém todas as opções configuráveis para o cliente de notificações
@@ -9,12 +30,29 @@ type ClientOptions struct {
 	// Endereço do servidor gRPC
 	ServerAddress string
 
+	// Endereços do servidor gRPC, em ordem de preferência (ex.: região primária e secundária)
+	ServerAddresses []string
+
+	// Política de balanceamento entre os endereços (PICK_FIRST ou ROUND_ROBIN)
+	LoadBalancing string
+
+	// Chamado quando muda o endpoint que atende as chamadas (ex.: para exportar em métricas)
+	EndpointObserver EndpointObserver
+
 	// Timeout para conexões gRPC
 	Timeout time.Duration
 
 	// Tentativas máximas de reconexão
 	MaxRetries int
 
+	// Tentativas máximas por severidade (INFO, WARNING, CRITICAL); as ausentes usam MaxRetries.
+	// Enquanto MaxRetries não é definido por WithMaxRetries, CRITICAL tem 6 e INFO tem 1.
+	SeverityRetries map[string]int
+
+	// Indica que MaxRetries foi definido por WithMaxRetries, desativando as tentativas padrão
+	// por severidade
+	maxRetriesSet bool
+
 	// Intervalo entre tentativas de reconexão
 	RetryInterval time.Duration
 
@@ -26,27 +64,412 @@ type ClientOptions struct {
 
 	// Origin identifica o serviço que está enviando a notificação
 	Origin string
+
+	// Servidor chamado diretamente, sem rede (transporte em processo)
+	InProcessServer notifications.NotificationsServiceServer
+
+	// Interceptors unários executados a cada tentativa, na ordem em que foram registrados
+	UnaryInterceptors []grpc.UnaryClientInterceptor
+
+	// Opções de dial adicionais, aplicadas depois das opções da biblioteca
+	DialOptions []grpc.DialOption
+
+	// Opções adicionais aplicadas a todas as chamadas gRPC
+	CallOptions []grpc.CallOption
+
+	// Compressor usado nas requisições (ex.: "gzip"); vazio desabilita a compressão
+	Compression string
+
+	// Tamanho mínimo, em bytes, para que uma requisição seja comprimida
+	CompressionThreshold int
+
+	// Recebe os tamanhos de cada requisição antes e depois da compressão (ex.: para métricas)
+	CompressionObserver CompressionObserver
+
+	// Envios unários simultâneos em NotifyBatch quando o servidor não suporta o RPC em lote
+	BatchConcurrency int
+
+	// Idade máxima de uma notificação, medida a partir de Data.OccurredAt, no momento do envio;
+	// zero aceita notificações de qualquer idade
+	MaxEventAge time.Duration
+
+	// Enrichers executados antes do envio, na ordem em que foram registrados
+	Enrichers []Enricher
+
+	// Middlewares executados em volta de cada envio; o primeiro registrado é o mais externo
+	Middlewares []Middleware
+
+	// Indica que Middlewares é a cadeia completa, sem os passos padrão de validação e enrichers
+	CustomChain bool
+
+	// Capacidade da fila de NotifyAsync
+	AsyncQueueSize int
+
+	// Envios simultâneos da fila de NotifyAsync; definido no primeiro uso de NotifyAsync
+	AsyncWorkers int
+
+	// Espera máxima de uma notificação menos urgente na fila antes de passar à frente das
+	// mais urgentes; zero desabilita a proteção contra starvation
+	AsyncMaxWait time.Duration
+
+	// Valida e registra as notificações sem enviá-las
+	DryRun bool
+
+	// Recebe as notificações no modo dry run; nil registra no log padrão
+	DryRunRecorder DryRunRecorder
+
+	// Endereço de um servidor secundário que recebe uma cópia de cada notificação (ex.: uma
+	// nova versão do serviço); vazio desabilita o envio shadow
+	ShadowAddress string
+
+	// Recebe a comparação entre os envios ao servidor principal e ao shadow; nil envia
+	// apenas as divergências ao Sentry
+	ShadowReporter ShadowReporter
+
+	// Envios shadow simultâneos; com o limite atingido, a cópia da notificação é descartada
+	ShadowConcurrency int
 }
 
 // DefaultOptions retorna as opções padrão para o cliente
 func DefaultOptions() *ClientOptions {
 	return &ClientOptions{
 		ServerAddress: "", // ServerAddress deve ser configurado explicitamente
+		LoadBalancing: PICK_FIRST,
 		Timeout:       time.Second * 10,
 		MaxRetries:    3,
 		RetryInterval: time.Second * 2,
 		EnableTLS:     false,
 		Origin:        "",
+
+		CompressionThreshold: 1024,
+		BatchConcurrency:     8,
+
+		AsyncQueueSize: 10000,
+		AsyncWorkers:   4,
+		AsyncMaxWait:   time.Second * 30,
+
+		ShadowConcurrency: 16,
 	}
 }
 
 // Option é um tipo para funções de configuração
 type Option func(*ClientOptions)
 
+// EndpointObserver recebe o endpoint anterior e o novo endpoint que atende as chamadas;
+// previous é vazio na primeira chamada
+type EndpointObserver func(previous, current string)
+
+// applyOptions aplica as opções sobre as opções padrão
+func applyOptions(opts []Option) *ClientOptions {
+	options := DefaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// Validate verifica as opções e reporta todos os problemas encontrados de uma vez
+func (o *ClientOptions) Validate() error {
+	var errs []error
+
+	// Verifica se origin foi configurado
+	if o.Origin == "" {
+		errs = append(errs, fmt.Errorf("a origem (Origin) do serviço deve ser configurada usando WithOrigin()"))
+	}
+
+	// Verifica se ServerAddress foi configurado
+	if len(o.addresses()) == 0 && o.InProcessServer == nil {
+		errs = append(errs, fmt.Errorf("o endereço do servidor (ServerAddress) deve ser configurado explicitamente usando WithServerAddress() ou WithServerAddresses()"))
+	}
+	for _, address := range o.ServerAddresses {
+		if strings.TrimSpace(address) == "" {
+			errs = append(errs, fmt.Errorf("a lista de endereços do servidor (ServerAddresses) contém um endereço vazio"))
+			break
+		}
+	}
+
+	// Verifica se a política de balanceamento é suportada
+	if o.LoadBalancing != PICK_FIRST && o.LoadBalancing != ROUND_ROBIN {
+		errs = append(errs, fmt.Errorf("política de balanceamento inválida: %s. Use PICK_FIRST ou ROUND_ROBIN", o.LoadBalancing))
+	}
+
+	if o.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("o timeout (Timeout) deve ser maior que zero"))
+	}
+	if o.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("o número máximo de tentativas (MaxRetries) não pode ser negativo"))
+	}
+	for severity, retries := range o.SeverityRetries {
+		if !slices.Contains(Severities(), severity) {
+			errs = append(errs, fmt.Errorf("severidade inválida em SeverityRetries: %s. Use INFO, WARNING ou CRITICAL", severity))
+		} else if retries < 0 {
+			errs = append(errs, fmt.Errorf("o número máximo de tentativas para %s não pode ser negativo", severity))
+		}
+	}
+	if o.RetryInterval < 0 {
+		errs = append(errs, fmt.Errorf("o intervalo entre tentativas (RetryInterval) não pode ser negativo"))
+	}
+	if o.EnableTLS && o.TLSCertPath == "" {
+		errs = append(errs, fmt.Errorf("o certificado TLS (TLSCertPath) deve ser informado quando TLS está habilitado"))
+	}
+
+	// Verifica se o compressor está registrado
+	if o.Compression != "" && encoding.GetCompressor(o.Compression) == nil {
+		errs = append(errs, fmt.Errorf("compressor não registrado: %s", o.Compression))
+	}
+	if o.CompressionThreshold < 0 {
+		errs = append(errs, fmt.Errorf("o tamanho mínimo para compressão (CompressionThreshold) não pode ser negativo"))
+	}
+	if o.BatchConcurrency < 1 {
+		errs = append(errs, fmt.Errorf("a concorrência de envio em lote (BatchConcurrency) deve ser maior que zero"))
+	}
+	if o.MaxEventAge < 0 {
+		errs = append(errs, fmt.Errorf("a idade máxima das notificações (MaxEventAge) não pode ser negativa"))
+	}
+	if o.AsyncQueueSize < 1 {
+		errs = append(errs, fmt.Errorf("a capacidade da fila assíncrona (AsyncQueueSize) deve ser maior que zero"))
+	}
+	if o.AsyncWorkers < 1 {
+		errs = append(errs, fmt.Errorf("a concorrência de envio assíncrono (AsyncWorkers) deve ser maior que zero"))
+	}
+	if o.AsyncMaxWait < 0 {
+		errs = append(errs, fmt.Errorf("a espera máxima na fila assíncrona (AsyncMaxWait) não pode ser negativa"))
+	}
+
+	if o.ShadowConcurrency < 1 {
+		errs = append(errs, fmt.Errorf("a concorrência de envios shadow (ShadowConcurrency) deve ser maior que zero"))
+	}
+	// Um shadow apontando para o servidor principal duplicaria as notificações
+	if o.ShadowAddress != "" && slices.Contains(o.addresses(), o.ShadowAddress) {
+		errs = append(errs, fmt.Errorf("o endereço shadow (ShadowAddress) deve ser diferente dos endereços do servidor: %s", o.ShadowAddress))
+	}
+
+	return errors.Join(errs...)
+}
+
 // WithServerAddress define o endereço do servidor
 func WithServerAddress(address string) Option {
 	return func(o *ClientOptions) {
 		o.ServerAddress = address
+		o.ServerAddresses = nil
+	}
+}
+
+// WithServerAddresses define vários endereços do servidor, em ordem de preferência.
+// Com PICK_FIRST o primeiro endereço saudável é usado e os demais servem de failover;
+// com ROUND_ROBIN as requisições são distribuídas entre todos.
+func WithServerAddresses(addresses ...string) Option {
+	return func(o *ClientOptions) {
+		o.ServerAddress = ""
+		o.ServerAddresses = addresses
+	}
+}
+
+// WithUnixSocket conecta a um relay local de notificações através de um Unix domain socket.
+// O caminho pode ser absoluto ou relativo ao diretório de trabalho.
+func WithUnixSocket(path string) Option {
+	return func(o *ClientOptions) {
+		o.ServerAddress = unixTarget(path)
+		o.ServerAddresses = nil
+	}
+}
+
+// unixTarget retorna o endereço gRPC do socket: "unix:///caminho" para caminhos absolutos e
+// "unix:caminho" para relativos, já que em "unix://caminho" o gRPC trataria o início do
+// caminho relativo como authority. Endereços que já começam com "unix:" são mantidos.
+func unixTarget(path string) string {
+	if strings.HasPrefix(path, "unix:") {
+		return path
+	}
+	if strings.HasPrefix(path, "/") {
+		return "unix://" + path
+	}
+	return "unix:" + path
+}
+
+// WithInProcessServer faz o cliente chamar o servidor diretamente, sem rede.
+// Validação, interceptors e retentativas continuam sendo executados normalmente.
+func WithInProcessServer(server notifications.NotificationsServiceServer) Option {
+	return func(o *ClientOptions) {
+		o.InProcessServer = server
+	}
+}
+
+// WithUnaryInterceptors adiciona interceptors unários à conexão.
+// Os interceptors rodam dentro do laço de retentativas, ou seja, uma vez por tentativa,
+// e o primeiro registrado é o mais externo.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *ClientOptions) {
+		o.UnaryInterceptors = append(o.UnaryInterceptors, interceptors...)
+	}
+}
+
+// WithDialOptions adiciona opções de dial (compressão, tamanho máximo de mensagem, etc.).
+// Elas são aplicadas depois das opções da biblioteca e prevalecem em caso de conflito.
+func WithDialOptions(dialOpts ...grpc.DialOption) Option {
+	return func(o *ClientOptions) {
+		o.DialOptions = append(o.DialOptions, dialOpts...)
+	}
+}
+
+// WithCallOptions adiciona opções aplicadas a todas as chamadas gRPC do cliente
+func WithCallOptions(callOpts ...grpc.CallOption) Option {
+	return func(o *ClientOptions) {
+		o.CallOptions = append(o.CallOptions, callOpts...)
+	}
+}
+
+// WithCompression habilita a compressão das requisições com o compressor informado (ex.: "gzip").
+// Apenas requisições com tamanho a partir de CompressionThreshold são comprimidas.
+func WithCompression(name string) Option {
+	return func(o *ClientOptions) {
+		o.Compression = name
+	}
+}
+
+// WithCompressionThreshold define o tamanho mínimo, em bytes, para comprimir uma requisição
+func WithCompressionThreshold(bytes int) Option {
+	return func(o *ClientOptions) {
+		o.CompressionThreshold = bytes
+	}
+}
+
+// WithCompressionObserver define uma função que recebe os tamanhos de cada requisição antes e
+// depois da compressão, para acompanhar a taxa de compressão em métricas. Não é chamada no
+// transporte em processo, que não serializa as requisições.
+func WithCompressionObserver(observer CompressionObserver) Option {
+	return func(o *ClientOptions) {
+		o.CompressionObserver = observer
+	}
+}
+
+// WithBatchConcurrency define quantos envios unários NotifyBatch faz em paralelo
+// quando o servidor não suporta o RPC em lote
+func WithBatchConcurrency(concurrency int) Option {
+	return func(o *ClientOptions) {
+		o.BatchConcurrency = concurrency
+	}
+}
+
+// WithMaxEventAge faz o cliente rejeitar, com ErrEventTooOld, notificações cujo OccurredAt
+// seja mais antigo que a idade informada no momento do envio, como eventos antigos de um replay
+// ou que esperaram demais na fila assíncrona
+func WithMaxEventAge(age time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.MaxEventAge = age
+	}
+}
+
+// WithEnrichers adiciona enrichers que acrescentam metadados a todas as notificações,
+// como HostEnricher, BuildInfoEnricher e TraceEnricher. As chaves informadas pelo chamador
+// têm prioridade, seguidas pelas dos enrichers registrados primeiro.
+func WithEnrichers(enrichers ...Enricher) Option {
+	return func(o *ClientOptions) {
+		o.Enrichers = append(o.Enrichers, enrichers...)
+	}
+}
+
+// WithMiddleware adiciona middlewares em volta do envio de cada notificação, inclusive as de
+// NotifyAsync e NotifyBatch. Na cadeia padrão, eles recebem a notificação já preenchida pelo
+// contexto, validada e enriquecida, e o primeiro registrado é o mais externo.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *ClientOptions) {
+		o.Middlewares = append(o.Middlewares, middlewares...)
+	}
+}
+
+// WithMiddlewareChain substitui a cadeia de middlewares inteira, inclusive os passos padrão de
+// validação e enrichers, que passam a ser executados apenas onde ValidationMiddleware e
+// EnrichmentMiddleware forem incluídos. Permite, por exemplo, um middleware que preenche Scope
+// antes da validação ou uma amostragem antes dos enrichers:
+//
+//	notify.WithMiddlewareChain(fillScope, sample, notify.ValidationMiddleware(), notify.EnrichmentMiddleware())
+//
+// As notificações continuam sendo validadas antes do envio ao servidor.
+func WithMiddlewareChain(middlewares ...Middleware) Option {
+	return func(o *ClientOptions) {
+		o.Middlewares = slices.Clone(middlewares)
+		o.CustomChain = true
+	}
+}
+
+// WithAsyncQueueSize define quantas notificações a fila de NotifyAsync comporta
+func WithAsyncQueueSize(size int) Option {
+	return func(o *ClientOptions) {
+		o.AsyncQueueSize = size
+	}
+}
+
+// WithAsyncWorkers define quantas notificações da fila de NotifyAsync são enviadas em paralelo
+func WithAsyncWorkers(workers int) Option {
+	return func(o *ClientOptions) {
+		o.AsyncWorkers = workers
+	}
+}
+
+// WithAsyncMaxWait define quanto tempo uma notificação menos urgente pode esperar na fila
+// de NotifyAsync antes de passar à frente das mais urgentes
+func WithAsyncMaxWait(wait time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.AsyncMaxWait = wait
+	}
+}
+
+// WithDryRun faz o cliente executar todo o caminho de envio (contexto, validação, enrichers,
+// middlewares e conversão para as requisições gRPC) sem enviar as notificações, que são
+// entregues ao DryRunRecorder. Notify retorna um Result sem ID e com zero tentativas.
+func WithDryRun() Option {
+	return func(o *ClientOptions) {
+		o.DryRun = true
+	}
+}
+
+// WithDryRunRecorder define quem recebe as notificações no modo dry run, como JSONLRecorder.
+// Sem recorder, elas são registradas no log padrão.
+func WithDryRunRecorder(recorder DryRunRecorder) Option {
+	return func(o *ClientOptions) {
+		o.DryRunRecorder = recorder
+	}
+}
+
+// WithShadow envia uma cópia de cada notificação ao servidor informado, em paralelo ao envio
+// principal, com as mesmas opções de conexão e uma única tentativa. O resultado do servidor
+// principal é retornado sem esperar o shadow, e falhas do shadow não afetam o chamador.
+func WithShadow(address string) Option {
+	return func(o *ClientOptions) {
+		o.ShadowAddress = address
+	}
+}
+
+// WithShadowReporter define quem recebe a comparação entre os envios ao servidor principal
+// e ao shadow. Sem reporter, apenas as divergências são enviadas ao Sentry.
+func WithShadowReporter(reporter ShadowReporter) Option {
+	return func(o *ClientOptions) {
+		o.ShadowReporter = reporter
+	}
+}
+
+// WithShadowConcurrency define quantos envios shadow podem estar em andamento ao mesmo tempo.
+// Com o limite atingido, a cópia da notificação não é enviada ao shadow, para que um servidor
+// shadow lento não acumule goroutines no cliente.
+func WithShadowConcurrency(concurrency int) Option {
+	return func(o *ClientOptions) {
+		o.ShadowConcurrency = concurrency
+	}
+}
+
+// WithLoadBalancing define a política de balanceamento (PICK_FIRST ou ROUND_ROBIN)
+func WithLoadBalancing(policy string) Option {
+	return func(o *ClientOptions) {
+		o.LoadBalancing = policy
+	}
+}
+
+// WithEndpointObserver define uma função chamada sempre que o endpoint que atende as chamadas
+// muda, como em um failover, para que ele possa ser exportado em métricas ou logs
+func WithEndpointObserver(observer EndpointObserver) Option {
+	return func(o *ClientOptions) {
+		o.EndpointObserver = observer
 	}
 }
 
@@ -57,10 +480,24 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
-// WithMaxRetries define o número máximo de tentativas de reconexão
+// WithMaxRetries define o número máximo de tentativas de reconexão. No envio de notificações,
+// vale para todas as severidades sem tentativas próprias definidas por WithSeverityRetries,
+// substituindo também as tentativas padrão de CRITICAL e INFO.
 func WithMaxRetries(retries int) Option {
 	return func(o *ClientOptions) {
 		o.MaxRetries = retries
+		o.maxRetriesSet = true
+	}
+}
+
+// WithSeverityRetries define o número máximo de tentativas das notificações com a severidade
+// informada, permitindo insistir mais em notificações críticas e desistir antes das informativas
+func WithSeverityRetries(severity string, retries int) Option {
+	return func(o *ClientOptions) {
+		if o.SeverityRetries == nil {
+			o.SeverityRetries = make(map[string]int)
+		}
+		o.SeverityRetries[severity] = retries
 	}
 }
 
@@ -85,3 +522,75 @@ func WithOrigin(origin string) Option {
 		o.Origin = origin
 	}
 }
+
+// addresses retorna a lista de endereços configurados, na ordem de preferência
+func (o *ClientOptions) addresses() []string {
+	if len(o.ServerAddresses) > 0 {
+		return o.ServerAddresses
+	}
+	if o.ServerAddress != "" {
+		return []string{o.ServerAddress}
+	}
+	return nil
+}
+
+// checkEventAge verifica se a notificação não é mais antiga que MaxEventAge
+func (o *ClientOptions) checkEventAge(params *Data) error {
+	if o.MaxEventAge == 0 || params.OccurredAt.IsZero() {
+		return nil
+	}
+	if age := time.Since(params.OccurredAt); age > o.MaxEventAge {
+		return fmt.Errorf("%w: ocorreu há %s, máximo de %s", ErrEventTooOld, age.Round(time.Second), o.MaxEventAge)
+	}
+	return nil
+}
+
+// Tentativas padrão por severidade, usadas enquanto MaxRetries não é definido: notificações
+// críticas insistem mais que as demais e as informativas desistem antes
+var defaultSeverityRetries = map[string]int{CRITICAL: 6, INFO: 1}
+
+// maxRetries retorna o número máximo de tentativas para a severidade
+func (o *ClientOptions) maxRetries(severity string) int {
+	if retries, ok := o.SeverityRetries[severity]; ok {
+		return retries
+	}
+	if retries, ok := defaultSeverityRetries[severity]; ok && !o.maxRetriesSet {
+		return retries
+	}
+	return o.MaxRetries
+}
+
+// callOptions retorna as opções de chamada da biblioteca seguidas das configuradas pelo usuário.
+// A compressão só é aplicada quando a requisição atinge o tamanho mínimo configurado.
+func (o *ClientOptions) callOptions(req proto.Message, p *peer.Peer) []grpc.CallOption {
+	callOpts := []grpc.CallOption{grpc.Peer(p)}
+	if o.Compression != "" && proto.Size(req) >= o.CompressionThreshold {
+		callOpts = append(callOpts, grpc.UseCompressor(o.Compression))
+	}
+	return append(callOpts, o.CallOptions...)
+}
+
+// clone retorna uma cópia das opções que pode ser alterada sem afetar a original
+func (o *ClientOptions) clone() *ClientOptions {
+	c := *o
+	c.ServerAddresses = slices.Clip(c.ServerAddresses)
+	c.UnaryInterceptors = slices.Clip(c.UnaryInterceptors)
+	c.DialOptions = slices.Clip(c.DialOptions)
+	c.CallOptions = slices.Clip(c.CallOptions)
+	c.Enrichers = slices.Clip(c.Enrichers)
+	c.Middlewares = slices.Clip(c.Middlewares)
+	c.SeverityRetries = maps.Clone(c.SeverityRetries)
+	return &c
+}
+
+// tlsModTime retorna a data de modificação do certificado TLS, ou zero se TLS não estiver habilitado
+func (o *ClientOptions) tlsModTime() time.Time {
+	if !o.EnableTLS {
+		return time.Time{}
+	}
+	info, err := os.Stat(o.TLSCertPath)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
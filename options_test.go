@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnixTarget(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/run/notify.sock", "unix:///run/notify.sock"},
+		{"notify.sock", "unix:notify.sock"},
+		{"run/notify.sock", "unix:run/notify.sock"},
+		{"unix:///run/notify.sock", "unix:///run/notify.sock"},
+		{"unix:notify.sock", "unix:notify.sock"},
+	}
+	for _, tt := range tests {
+		if got := unixTarget(tt.path); got != tt.want {
+			t.Errorf("unixTarget(%q) = %q, esperado %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestWithUnixSocketRelativePath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	server := &fakeServer{}
+	startServer(t, server, withUnixListener("notify.sock"))
+
+	c, err := NewClient(WithUnixSocket("notify.sock"), WithOrigin("test"), WithRetryInterval(0), WithTimeout(3*time.Second))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Notify(context.Background(), testData("p1")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got := len(server.received()); got != 1 {
+		t.Fatalf("servidor recebeu %d notificações, esperado 1", got)
+	}
+}
+
+func TestDefaultSeverityRetries(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		calls map[string]int32
+	}{
+		{"padrão", nil, map[string]int32{INFO: 2, WARNING: 4, CRITICAL: 7}},
+		{"WithMaxRetries(0)", []Option{WithMaxRetries(0)}, map[string]int32{INFO: 1, WARNING: 1, CRITICAL: 1}},
+		{"WithMaxRetries(2)", []Option{WithMaxRetries(2)}, map[string]int32{INFO: 3, WARNING: 3, CRITICAL: 3}},
+		{"WithSeverityRetries sobre WithMaxRetries", []Option{WithSeverityRetries(CRITICAL, 4), WithMaxRetries(0)}, map[string]int32{INFO: 1, WARNING: 1, CRITICAL: 5}},
+		{"WithSeverityRetries sem WithMaxRetries", []Option{WithSeverityRetries(INFO, 0)}, map[string]int32{INFO: 1, WARNING: 4, CRITICAL: 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeServer{notify: func(ctx context.Context, req *notifications.NotifyRequest) error {
+				return status.Error(codes.Unavailable, "fora do ar")
+			}}
+			c := newTestClient(t, server, tt.opts...)
+
+			for _, severity := range Severities() {
+				server.calls.Store(0)
+				params := testData("p1")
+				params.Severity = severity
+				if err := c.Notify(context.Background(), params); err == nil {
+					t.Fatalf("%s: esperado erro", severity)
+				}
+				if calls := server.calls.Load(); calls != tt.calls[severity] {
+					t.Errorf("%s: %d tentativas, esperado %d", severity, calls, tt.calls[severity])
+				}
+			}
+		})
+	}
+}
+
+func TestConfigMaxRetriesOverridesSeverityDefaults(t *testing.T) {
+	t.Setenv(EnvMaxRetries, "0")
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv: %v", err)
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		t.Fatalf("Options: %v", err)
+	}
+	options := applyOptions(opts)
+	for _, severity := range Severities() {
+		if got := options.maxRetries(severity); got != 0 {
+			t.Errorf("%s: %d tentativas com %s=0, esperado 0", severity, got, EnvMaxRetries)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+package notifications
+
+// O código deste pacote e de v2 é gerado a partir dos arquivos em proto/
+//go:generate make -C ../.. proto
@@ -0,0 +1,184 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// Reload aplica as opções sobre a configuração atual e a troca atomicamente no cliente.
+// Mudanças de endereço, balanceamento, TLS (inclusive um certificado renovado) ou servidor em
+// processo abrem uma nova conexão, assim como qualquer recarga com interceptors, opções de
+// dial ou CompressionObserver configurados, que não podem ser comparados; a antiga é fechada depois de Timeout, para
+// que as chamadas em andamento terminem. Se a nova configuração for inválida ou a conexão falhar,
+// a configuração atual é mantida.
+func (c *NotifyClient) Reload(opts ...Option) error {
+	return c.reload(func(current *ClientOptions) *ClientOptions {
+		options := current.clone()
+		for _, opt := range opts {
+			opt(options)
+		}
+		return options
+	})
+}
+
+// reloadConfig substitui a configuração pela de um arquivo. As novas opções partem das
+// opções padrão, seguidas das informadas na criação do cliente e das do arquivo, para que
+// campos removidos do arquivo voltem ao valor original em vez de manter o anterior.
+func (c *NotifyClient) reloadConfig(fileOpts []Option) error {
+	return c.reload(func(*ClientOptions) *ClientOptions {
+		opts := slices.Concat(c.baseOpts, fileOpts, c.overrideOpts)
+		return applyOptions(opts)
+	})
+}
+
+// reload troca a configuração pelas opções retornadas por build a partir das atuais
+func (c *NotifyClient) reload(build func(current *ClientOptions) *ClientOptions) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.closed:
+		return fmt.Errorf("não é possível recarregar a configuração de um cliente fechado")
+	default:
+	}
+
+	current := c.state.Load()
+	options := build(current.options)
+
+	if err := options.Validate(); err != nil {
+		return fmt.Errorf("configuração inválida, a configuração atual foi mantida: %w", err)
+	}
+
+	// Apenas retentativas, timeouts e afins mudaram: a conexão atual é mantida
+	if !current.needsRedial(options) {
+		next := *current
+		next.options = options
+		c.state.Store(&next)
+
+		// O shadow acompanha as retentativas e timeouts do cliente principal
+		if shadow := current.shadow; shadow != nil {
+			shadowState := *shadow.state.Load()
+			shadowState.options = options.shadowOptions()
+			shadow.state.Store(&shadowState)
+		}
+		return nil
+	}
+
+	next, err := newClientState(options)
+	if err != nil {
+		return fmt.Errorf("falha ao reconectar, a configuração atual foi mantida: %w", err)
+	}
+	c.state.Store(next)
+
+	// O novo servidor pode suportar o que o anterior não suportava
+	c.batchUnsupported.Store(false)
+	c.v2Unsupported.Store(false)
+
+	if options.InProcessServer != nil {
+		c.setActiveEndpoint(options, inProcessEndpoint)
+	} else {
+		c.setActiveEndpoint(options, options.addresses()[0])
+	}
+
+	// Fecha a conexão antiga depois que as chamadas em andamento tiverem tempo de terminar
+	if current.conn != nil || current.shadow != nil {
+		time.AfterFunc(current.options.Timeout, func() {
+			current.close()
+		})
+	}
+
+	return nil
+}
+
+// WatchConfigFile monitora um arquivo de configuração JSON ou YAML e recarrega a configuração
+// quando ele muda. A nova configuração é montada a partir das opções padrão, das opções
+// informadas na criação do cliente e do arquivo, nessa ordem: campos removidos do arquivo
+// voltam ao valor original, e alterações feitas por Reload são substituídas.
+// O certificado TLS configurado também é monitorado e a conexão é refeita quando ele é renovado.
+// O monitoramento termina quando o cliente é fechado; erros ao recarregar são enviados ao Sentry,
+// a configuração atual é mantida e o arquivo é lido de novo na próxima verificação.
+func (c *NotifyClient) WatchConfigFile(path string, interval time.Duration) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("falha ao monitorar arquivo de configuração: %w", err)
+	}
+
+	go c.watchConfigFile(path, interval, info.ModTime())
+	return nil
+}
+
+// watchConfigFile verifica o arquivo periodicamente até o cliente ser fechado
+func (c *NotifyClient) watchConfigFile(path string, interval time.Duration, modTime time.Time) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ticker.C:
+		}
+
+		if err := c.reloadIfChanged(path, &modTime); err != nil {
+			// Captura erro no Sentry, se configurado
+			sentry.CaptureException(fmt.Errorf("falha ao recarregar configuração de %s: %w", path, err))
+		}
+	}
+}
+
+// reloadIfChanged recarrega a configuração se o arquivo ou o certificado TLS foram modificados
+func (c *NotifyClient) reloadIfChanged(path string, modTime *time.Time) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.ModTime().Equal(*modTime) {
+		state := c.state.Load()
+		if state.options.EnableTLS && !state.options.tlsModTime().Equal(state.tlsModTime) {
+			return c.Reload()
+		}
+		return nil
+	}
+
+	// A data de modificação só é registrada depois que o arquivo é aplicado, para que um arquivo
+	// ainda incompleto ou inválido seja lido de novo na próxima verificação
+	config, err := LoadConfigFile(path)
+	if err != nil {
+		return err
+	}
+	opts, err := config.Options()
+	if err != nil {
+		return err
+	}
+	if err := c.reloadConfig(opts); err != nil {
+		return err
+	}
+	*modTime = info.ModTime()
+	return nil
+}
+
+// needsRedial indica se as novas opções exigem uma nova conexão
+func (s *clientState) needsRedial(next *ClientOptions) bool {
+	current := s.options
+	return !slices.Equal(current.addresses(), next.addresses()) ||
+		current.LoadBalancing != next.LoadBalancing ||
+		current.EnableTLS != next.EnableTLS ||
+		current.TLSCertPath != next.TLSCertPath ||
+		!next.tlsModTime().Equal(s.tlsModTime) ||
+		len(current.UnaryInterceptors) != len(next.UnaryInterceptors) ||
+		len(current.DialOptions) != len(next.DialOptions) ||
+		current.ShadowAddress != next.ShadowAddress ||
+		current.InProcessServer != next.InProcessServer ||
+		// Funções não podem ser comparadas: interceptors e opções de dial presentes nas novas
+		// opções sempre refazem a conexão, para que os novos valores sejam aplicados. O
+		// observador de compressão fica na conexão, que é refeita também para removê-lo.
+		len(next.UnaryInterceptors) > 0 ||
+		len(next.DialOptions) > 0 ||
+		current.CompressionObserver != nil ||
+		next.CompressionObserver != nil
+}
@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestReloadConfigFileRestoresRemovedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("origin: test\nmax_event_age: 1h\nmax_retries: 7\nseverity_retries:\n  INFO: 0\n")
+	c, err := NewClientFromFile(path, WithInProcessServer(&fakeServer{}), WithTimeout(3*time.Second))
+	if err != nil {
+		t.Fatalf("NewClientFromFile: %v", err)
+	}
+	defer c.Close()
+
+	options := c.state.Load().options
+	if options.MaxEventAge != time.Hour || options.MaxRetries != 7 || options.SeverityRetries[INFO] != 0 {
+		t.Fatalf("configuração inicial não aplicada: %+v", options)
+	}
+
+	write("origin: test\ntimeout: 1s\n")
+	var modTime time.Time
+	if err := c.reloadIfChanged(path, &modTime); err != nil {
+		t.Fatalf("reloadIfChanged: %v", err)
+	}
+
+	options = c.state.Load().options
+	defaults := DefaultOptions()
+	if options.MaxEventAge != 0 {
+		t.Errorf("MaxEventAge = %s, esperado 0 após remover a chave", options.MaxEventAge)
+	}
+	if options.MaxRetries != defaults.MaxRetries {
+		t.Errorf("MaxRetries = %d, esperado o padrão %d", options.MaxRetries, defaults.MaxRetries)
+	}
+	if got := options.maxRetries(INFO); got != defaultSeverityRetries[INFO] {
+		t.Errorf("tentativas de INFO = %d, esperado o padrão %d", got, defaultSeverityRetries[INFO])
+	}
+
+	// As opções informadas na criação continuam prevalecendo sobre o arquivo
+	if options.Timeout != 3*time.Second {
+		t.Errorf("Timeout = %s, esperado 3s da opção informada na criação", options.Timeout)
+	}
+	if options.InProcessServer == nil {
+		t.Errorf("servidor em processo informado na criação foi perdido")
+	}
+}
+
+func TestReloadRedialsOnReplacedValues(t *testing.T) {
+	first, second := &fakeServer{}, &fakeServer{}
+	var firstCalls, secondCalls atomic.Int32
+	counter := func(calls *atomic.Int32) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			calls.Add(1)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	c := newTestClient(t, first, WithUnaryInterceptors(counter(&firstCalls)))
+
+	// Troca o servidor em processo e o interceptor, mantendo a mesma quantidade de cada um
+	err := c.reload(func(current *ClientOptions) *ClientOptions {
+		options := current.clone()
+		options.InProcessServer = second
+		options.UnaryInterceptors = []grpc.UnaryClientInterceptor{counter(&secondCalls)}
+		return options
+	})
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	if err := c.Notify(context.Background(), testData("p1")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if len(first.received()) != 0 || len(second.received()) != 1 {
+		t.Errorf("servidores receberam %d e %d notificações, esperado 0 e 1", len(first.received()), len(second.received()))
+	}
+	if firstCalls.Load() != 0 || secondCalls.Load() != 1 {
+		t.Errorf("interceptors executados %d e %d vezes, esperado 0 e 1", firstCalls.Load(), secondCalls.Load())
+	}
+}
+
+func TestReloadRetriesInvalidConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	initial := time.Now().Add(-time.Hour)
+	write("origin: test\n", initial)
+	c, err := NewClientFromFile(path, WithInProcessServer(&fakeServer{}))
+	if err != nil {
+		t.Fatalf("NewClientFromFile: %v", err)
+	}
+	defer c.Close()
+
+	// Arquivo ainda incompleto quando a mudança é detectada
+	modTime := initial
+	changed := initial.Add(time.Minute)
+	write("origin: test\ntimeout: [", changed)
+	if err := c.reloadIfChanged(path, &modTime); err == nil {
+		t.Fatal("esperado erro para o arquivo inválido")
+	}
+	if !modTime.Equal(initial) {
+		t.Errorf("data de modificação registrada para um arquivo que não foi aplicado")
+	}
+
+	// Concluída a escrita com a mesma data de modificação, o arquivo é lido de novo
+	write("origin: test\ntimeout: 4s\n", changed)
+	if err := c.reloadIfChanged(path, &modTime); err != nil {
+		t.Fatalf("reloadIfChanged: %v", err)
+	}
+	if timeout := c.state.Load().options.Timeout; timeout != 4*time.Second {
+		t.Errorf("Timeout = %s, esperado 4s do arquivo corrigido", timeout)
+	}
+	if !modTime.Equal(changed) {
+		t.Errorf("data de modificação %s, esperado %s", modTime, changed)
+	}
+}
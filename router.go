@@ -0,0 +1,212 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrNoRoute é retornado por Router.Send quando nenhuma rota aceita a notificação
+var ErrNoRoute = errors.New("nenhuma rota para a notificação")
+
+// Predicate decide se uma notificação segue por uma rota
+type Predicate func(params *Data) bool
+
+// Route entrega aos sinks as notificações aceitas por Match; Match nil aceita todas
+type Route struct {
+	Match Predicate
+	Sinks []Sink
+}
+
+// Delivery é o resultado da entrega de uma notificação a um sink
+type Delivery struct {
+	// Nome do sink
+	Sink string
+
+	// Resultado do sink; nil quando houve erro
+	Result *Result
+
+	// Erro de entrega; nil quando o sink aceitou a notificação
+	Err error
+}
+
+// Router entrega cada notificação a todos os sinks das rotas que a aceitam
+type Router struct {
+	routes []Route
+}
+
+// NewRouter cria um roteador com as rotas informadas. Sinks são identificados pelo nome:
+// um sink presente em mais de uma rota recebe cada notificação uma única vez, e sinks
+// diferentes devem ter nomes diferentes.
+func NewRouter(routes ...Route) (*Router, error) {
+	var errs []error
+	for i, route := range routes {
+		if len(route.Sinks) == 0 {
+			errs = append(errs, fmt.Errorf("a rota %d não tem sinks", i))
+		}
+		for _, sink := range route.Sinks {
+			if sink == nil {
+				errs = append(errs, fmt.Errorf("a rota %d contém um sink nulo", i))
+				continue
+			}
+			if sink.Name() == "" {
+				errs = append(errs, fmt.Errorf("a rota %d contém um sink sem nome", i))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &Router{routes: slices.Clone(routes)}, nil
+}
+
+// Send valida a notificação e a entrega, em paralelo, a todos os sinks das rotas que a aceitam.
+// Assim como em NotifyWithResult, os campos não informados são preenchidos pelo contexto e
+// OccurredAt pelo momento da chamada, para que todos os sinks recebam a mesma notificação.
+// Retorna uma entrega por sink, na ordem em que aparecem nas rotas, e um erro se alguma falhar.
+// Sem nenhuma rota aplicável, retorna ErrNoRoute.
+func (r *Router) Send(ctx context.Context, params *Data) ([]Delivery, error) {
+	if params == nil {
+		return nil, fmt.Errorf("parâmetros de notificação não podem ser nulos")
+	}
+	params, err := params.fromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("parâmetros inválidos: %w", err)
+	}
+	params = params.stamp(time.Now())
+
+	sinks := r.match(params)
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("%w: %s/%s do projeto %s", ErrNoRoute, params.Scope, params.Type, params.ProjectID)
+	}
+
+	deliveries := make([]Delivery, len(sinks))
+	var wg sync.WaitGroup
+	for i, sink := range sinks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Cada sink recebe sua cópia, para que um não veja as alterações do outro
+			result, err := sink.Send(ctx, params.clone())
+			deliveries[i] = Delivery{Sink: sink.Name(), Result: result, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, delivery := range deliveries {
+		if delivery.Err != nil {
+			errs = append(errs, fmt.Errorf("sink %s: %w", delivery.Sink, delivery.Err))
+		}
+	}
+	return deliveries, errors.Join(errs...)
+}
+
+// match retorna os sinks das rotas que aceitam a notificação, sem repetição
+func (r *Router) match(params *Data) []Sink {
+	var sinks []Sink
+	seen := make(map[string]bool)
+	for _, route := range r.routes {
+		if route.Match != nil && !route.Match(params) {
+			continue
+		}
+		for _, sink := range route.Sinks {
+			if seen[sink.Name()] {
+				continue
+			}
+			seen[sink.Name()] = true
+			sinks = append(sinks, sink)
+		}
+	}
+	return sinks
+}
+
+// MatchScope aceita as notificações com um dos escopos informados
+func MatchScope(scopes ...string) Predicate {
+	return func(params *Data) bool {
+		return slices.Contains(scopes, params.Scope)
+	}
+}
+
+// MatchType aceita as notificações com um dos tipos informados
+func MatchType(types ...string) Predicate {
+	return func(params *Data) bool {
+		return slices.Contains(types, params.Type)
+	}
+}
+
+// MatchProject aceita as notificações de um dos projetos informados
+func MatchProject(projectIDs ...string) Predicate {
+	return func(params *Data) bool {
+		return slices.Contains(projectIDs, params.ProjectID)
+	}
+}
+
+// MatchSeverity aceita as notificações com uma das severidades informadas,
+// considerando a severidade padrão do tipo quando Data.Severity não é informada
+func MatchSeverity(severities ...string) Predicate {
+	return func(params *Data) bool {
+		return slices.Contains(severities, params.severity())
+	}
+}
+
+// MatchMetadata aceita as notificações que têm a chave em Metadata ou StructuredMetadata.
+// Com valores informados, o valor da chave também deve ser um deles; em StructuredMetadata,
+// apenas valores string são comparados.
+func MatchMetadata(key string, values ...string) Predicate {
+	return func(params *Data) bool {
+		value, ok := params.Metadata[key]
+		if !ok {
+			structured, found := params.StructuredMetadata[key]
+			if !found {
+				return false
+			}
+			if len(values) == 0 {
+				return true
+			}
+			if value, ok = structured.(string); !ok {
+				return false
+			}
+		}
+		return len(values) == 0 || slices.Contains(values, value)
+	}
+}
+
+// MatchAll aceita as notificações aceitas por todos os predicados; sem predicados, aceita todas
+func MatchAll(predicates ...Predicate) Predicate {
+	return func(params *Data) bool {
+		for _, predicate := range predicates {
+			if !predicate(params) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchAny aceita as notificações aceitas por pelo menos um dos predicados
+func MatchAny(predicates ...Predicate) Predicate {
+	return func(params *Data) bool {
+		for _, predicate := range predicates {
+			if predicate(params) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not aceita as notificações rejeitadas pelo predicado
+func Not(predicate Predicate) Predicate {
+	return func(params *Data) bool {
+		return !predicate(params)
+	}
+}
@@ -0,0 +1,198 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	critical := testData("p1")
+	critical.Severity = CRITICAL
+	critical.Metadata = map[string]string{"canal": "email"}
+	critical.StructuredMetadata = map[string]any{"lote": "l1", "total": 3.0}
+
+	tests := []struct {
+		name      string
+		predicate Predicate
+		params    *Data
+		want      bool
+	}{
+		{"escopo", MatchScope(CAMPAIGN, SYSTEM), testData("p1"), true},
+		{"outro escopo", MatchScope(CAMPAIGN), testData("p1"), false},
+		{"tipo", MatchType(BOUNCE), testData("p1"), true},
+		{"outro tipo", MatchType(ISSUES), testData("p1"), false},
+		{"projeto", MatchProject("p0", "p1"), testData("p1"), true},
+		{"outro projeto", MatchProject("p2"), testData("p1"), false},
+		{"severidade informada", MatchSeverity(CRITICAL), critical, true},
+		{"severidade padrão do tipo", MatchSeverity(DefaultSeverity(BOUNCE)), testData("p1"), true},
+		{"outra severidade", MatchSeverity(INFO), critical, false},
+		{"chave de metadata", MatchMetadata("canal"), critical, true},
+		{"valor de metadata", MatchMetadata("canal", "sms", "email"), critical, true},
+		{"outro valor de metadata", MatchMetadata("canal", "sms"), critical, false},
+		{"chave estruturada", MatchMetadata("total"), critical, true},
+		{"valor estruturado string", MatchMetadata("lote", "l1"), critical, true},
+		{"valor estruturado não string", MatchMetadata("total", "3"), critical, false},
+		{"chave ausente", MatchMetadata("outra"), critical, false},
+		{"todos", MatchAll(MatchScope(SYSTEM), MatchSeverity(CRITICAL)), critical, true},
+		{"nem todos", MatchAll(MatchScope(SYSTEM), MatchSeverity(INFO)), critical, false},
+		{"todos sem predicados", MatchAll(), critical, true},
+		{"algum", MatchAny(MatchScope(CAMPAIGN), MatchSeverity(CRITICAL)), critical, true},
+		{"nenhum", MatchAny(MatchScope(CAMPAIGN), MatchSeverity(INFO)), critical, false},
+		{"algum sem predicados", MatchAny(), critical, false},
+		{"negação", Not(MatchScope(CAMPAIGN)), critical, true},
+	}
+	for _, tt := range tests {
+		if got := tt.predicate(tt.params); got != tt.want {
+			t.Errorf("%s: %v, esperado %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// recordingSink registra as notificações recebidas e retorna err em cada envio, se definido
+type recordingSink struct {
+	name string
+	err  error
+
+	mu       sync.Mutex
+	received []*Data
+}
+
+func (s *recordingSink) Name() string {
+	return s.name
+}
+
+func (s *recordingSink) Send(ctx context.Context, params *Data) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.received = append(s.received, params)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &Result{ID: s.name + "-" + params.ProjectID, Attempts: 1}, nil
+}
+
+// count retorna quantas notificações o sink recebeu
+func (s *recordingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.received)
+}
+
+func TestRouterSend(t *testing.T) {
+	pager, audit, chat := &recordingSink{name: "pager"}, &recordingSink{name: "audit"}, &recordingSink{name: "chat"}
+	router, err := NewRouter(
+		Route{Match: MatchSeverity(CRITICAL), Sinks: []Sink{pager, audit}},
+		Route{Match: MatchScope(SYSTEM), Sinks: []Sink{audit, chat}},
+		Route{Sinks: []Sink{audit}},
+	)
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		params   *Data
+		want     []string
+		received map[*recordingSink]int
+	}{
+		// O sink presente nas três rotas recebe a notificação uma única vez
+		{"todas as rotas", asyncData("p1", CRITICAL), []string{"pager", "audit", "chat"}, map[*recordingSink]int{pager: 1, audit: 1, chat: 1}},
+		{"rota de escopo e padrão", asyncData("p2", INFO), []string{"audit", "chat"}, map[*recordingSink]int{pager: 0, audit: 1, chat: 1}},
+		{"apenas a rota padrão", &Data{ProjectID: "p3", Scope: CAMPAIGN, Type: ISSUES, Severity: INFO}, []string{"audit"}, map[*recordingSink]int{pager: 0, audit: 1, chat: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := map[*recordingSink]int{pager: pager.count(), audit: audit.count(), chat: chat.count()}
+
+			deliveries, err := router.Send(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+
+			var names []string
+			for _, delivery := range deliveries {
+				names = append(names, delivery.Sink)
+				if delivery.Err != nil || delivery.Result == nil || delivery.Result.ID != delivery.Sink+"-"+tt.params.ProjectID {
+					t.Errorf("entrega inesperada: %+v", delivery)
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("sinks %v, esperado %v", names, tt.want)
+			}
+			for sink, want := range tt.received {
+				if got := sink.count() - before[sink]; got != want {
+					t.Errorf("sink %s recebeu %d notificações, esperado %d", sink.name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRouterCollectsSinkErrors(t *testing.T) {
+	errWebhook := errors.New("webhook fora do ar")
+	errFile := errors.New("disco cheio")
+	ok := &recordingSink{name: "ok"}
+	router, err := NewRouter(Route{Sinks: []Sink{
+		&recordingSink{name: "webhook", err: errWebhook},
+		ok,
+		&recordingSink{name: "file", err: errFile},
+	}})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	deliveries, err := router.Send(context.Background(), testData("p1"))
+	if !errors.Is(err, errWebhook) || !errors.Is(err, errFile) {
+		t.Fatalf("Send: %v, esperado os erros dos dois sinks", err)
+	}
+	if !strings.Contains(err.Error(), "sink webhook") || !strings.Contains(err.Error(), "sink file") {
+		t.Errorf("erro sem o nome dos sinks: %v", err)
+	}
+
+	if len(deliveries) != 3 {
+		t.Fatalf("%d entregas, esperado 3", len(deliveries))
+	}
+	if deliveries[0].Err != errWebhook || deliveries[2].Err != errFile {
+		t.Errorf("erros das entregas: %v, %v", deliveries[0].Err, deliveries[2].Err)
+	}
+	if deliveries[1].Err != nil || deliveries[1].Result == nil || ok.count() != 1 {
+		t.Errorf("o sink sem erro não recebeu a notificação: %+v", deliveries[1])
+	}
+}
+
+func TestRouterNoRouteAndInvalid(t *testing.T) {
+	sink := &recordingSink{name: "campaign"}
+	router, err := NewRouter(Route{Match: MatchScope(CAMPAIGN), Sinks: []Sink{sink}})
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	if _, err := router.Send(context.Background(), testData("p1")); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Send sem rota: %v, esperado ErrNoRoute", err)
+	}
+	if _, err := router.Send(context.Background(), &Data{ProjectID: "p1", Scope: CAMPAIGN, Type: "OUTRO"}); err == nil {
+		t.Error("notificação inválida aceita")
+	}
+	if sink.count() != 0 {
+		t.Errorf("sink recebeu %d notificações, esperado 0", sink.count())
+	}
+}
+
+func TestNewRouterInvalid(t *testing.T) {
+	_, err := NewRouter(
+		Route{},
+		Route{Sinks: []Sink{nil, &recordingSink{}}},
+	)
+	if err == nil {
+		t.Fatal("rotas inválidas aceitas")
+	}
+	for _, want := range []string{"rota 0 não tem sinks", "rota 1 contém um sink nulo", "rota 1 contém um sink sem nome"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("erro sem %q: %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"google.golang.org/grpc/status"
+)
+
+// ShadowResult compara o envio de uma notificação ao servidor principal com o envio da
+// mesma notificação ao servidor shadow
+type ShadowResult struct {
+	// Notificação enviada aos dois servidores
+	Params *Data
+
+	// Resultado e erro do servidor principal, como retornados ao chamador
+	Primary    *Result
+	PrimaryErr error
+
+	// Resultado e erro do servidor shadow
+	Shadow    *Result
+	ShadowErr error
+
+	// Duração de cada envio; a do principal inclui as retentativas
+	PrimaryLatency time.Duration
+	ShadowLatency  time.Duration
+}
+
+// Match indica se os dois servidores tiveram o mesmo desfecho: ambos criaram a notificação
+// ou ambos falharam com o mesmo código gRPC. IDs e datas de criação não são comparados.
+func (r *ShadowResult) Match() bool {
+	return status.Code(r.PrimaryErr) == status.Code(r.ShadowErr)
+}
+
+// ShadowReporter recebe a comparação de cada envio feito com WithShadow
+type ShadowReporter func(result *ShadowResult)
+
+// newShadowClient cria o cliente usado para o servidor shadow, com as mesmas opções de
+// conexão do cliente principal
+func newShadowClient(options *ClientOptions) (*NotifyClient, error) {
+	state, err := newClientState(options.shadowOptions())
+	if err != nil {
+		return nil, fmt.Errorf("falha ao criar conexão com o servidor shadow: %w", err)
+	}
+
+	shadow := &NotifyClient{
+		closed: make(chan struct{}),
+		async:  newAsyncQueue(),
+		quiet:  true,
+	}
+	shadow.state.Store(state)
+	return shadow, nil
+}
+
+// shadowOptions retorna as opções do cliente shadow. Enrichers e middlewares não são copiados,
+// pois a notificação chega ao shadow já processada pelo cliente principal. O shadow faz uma
+// única tentativa: retentativas atrasariam a comparação e multiplicariam a carga de um
+// servidor shadow com problemas.
+func (o *ClientOptions) shadowOptions() *ClientOptions {
+	shadow := o.clone()
+	shadow.ServerAddress = o.ShadowAddress
+	shadow.ServerAddresses = nil
+	shadow.InProcessServer = nil
+	shadow.ShadowAddress = ""
+	shadow.ShadowReporter = nil
+	shadow.EndpointObserver = nil
+	shadow.CompressionObserver = nil
+	shadow.Enrichers = nil
+	shadow.Middlewares = nil
+	shadow.CustomChain = false
+	shadow.DryRun = false
+	shadow.MaxRetries = 0
+	shadow.SeverityRetries = nil
+	shadow.maxRetriesSet = true
+	return shadow
+}
+
+// sendWithShadow envia a notificação ao servidor principal e, em paralelo, ao servidor shadow.
+// O resultado do principal é retornado sem esperar o shadow; a comparação é entregue ao
+// ShadowReporter quando os dois envios terminam. Com ShadowConcurrency envios shadow em
+// andamento, a notificação é enviada apenas ao principal.
+func (c *NotifyClient) sendWithShadow(ctx context.Context, state *clientState, params *Data) (*Result, error) {
+	if c.shadowInFlight.Add(1) > int64(state.options.ShadowConcurrency) {
+		c.shadowInFlight.Add(-1)
+		return c.send(ctx, state, params)
+	}
+
+	comparison := &ShadowResult{Params: params}
+	primaryDone := make(chan struct{})
+
+	// O shadow recebe sua cópia, pois a conversão para a requisição gRPC preenche os metadados
+	shadowParams := params.clone()
+
+	c.shadows.Add(1)
+	go func() {
+		defer c.shadows.Done()
+		defer c.shadowInFlight.Add(-1)
+
+		// O shadow não é cancelado junto com a chamada principal e usa o próprio timeout
+		shadow := state.shadow
+		start := time.Now()
+		result, err := shadow.send(context.WithoutCancel(ctx), shadow.state.Load(), shadowParams)
+		latency := time.Since(start)
+
+		<-primaryDone
+		comparison.Shadow, comparison.ShadowErr, comparison.ShadowLatency = result, err, latency
+		state.options.reportShadow(comparison)
+	}()
+
+	start := time.Now()
+	result, err := c.send(ctx, state, params)
+	if result != nil {
+		// Cópia, para que alterações do chamador não apareçam na comparação
+		primary := *result
+		comparison.Primary = &primary
+	}
+	comparison.PrimaryErr, comparison.PrimaryLatency = err, time.Since(start)
+	close(primaryDone)
+
+	return result, err
+}
+
+// reportShadow entrega a comparação ao ShadowReporter configurado. Sem reporter, apenas as
+// divergências são enviadas ao Sentry.
+func (o *ClientOptions) reportShadow(result *ShadowResult) {
+	if o.ShadowReporter != nil {
+		o.ShadowReporter(result)
+		return
+	}
+	if !result.Match() {
+		// Captura a divergência no Sentry, se configurado
+		sentry.CaptureException(fmt.Errorf("divergência no envio shadow para %s: principal %s (%v), shadow %s (%v)",
+			o.ShadowAddress, status.Code(result.PrimaryErr), result.PrimaryErr, status.Code(result.ShadowErr), result.ShadowErr))
+	}
+}
+
+// waitShadows aguarda até timeout os envios shadow em andamento
+func (c *NotifyClient) waitShadows(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		c.shadows.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+}
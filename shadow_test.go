@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShadowSingleAttemptReportsOnlyComparison(t *testing.T) {
+	captured := captureSentry(t)
+	shadow := &fakeServer{notify: func(ctx context.Context, req *notifications.NotifyRequest) error {
+		return status.Error(codes.Unavailable, "shadow fora do ar")
+	}}
+	c := newTestClient(t, &fakeServer{}, WithShadow(startServer(t, shadow)), WithMaxRetries(3), WithTimeout(3*time.Second))
+
+	if err := c.Notify(context.Background(), testData("p1")); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	c.waitShadows(3 * time.Second)
+
+	if calls := shadow.calls.Load(); calls != 1 {
+		t.Errorf("shadow recebeu %d chamadas, esperado 1", calls)
+	}
+	messages := captured()
+	if len(messages) != 1 || !strings.Contains(messages[0], "divergência") {
+		t.Errorf("erros enviados ao Sentry: %v, esperada apenas a divergência", messages)
+	}
+}
+
+func TestShadowConcurrencyDropsWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	shadow := &fakeServer{notify: func(ctx context.Context, req *notifications.NotifyRequest) error {
+		<-release
+		return nil
+	}}
+	primary := &fakeServer{}
+	reports := make(chan *ShadowResult, 10)
+	c := newTestClient(t, primary,
+		WithShadow(startServer(t, shadow)),
+		WithShadowConcurrency(2),
+		WithShadowReporter(func(result *ShadowResult) { reports <- result }),
+		WithTimeout(3*time.Second),
+	)
+
+	for i := range 5 {
+		if err := c.Notify(context.Background(), testData("p1")); err != nil {
+			t.Fatalf("envio %d: %v", i, err)
+		}
+	}
+	close(release)
+	c.waitShadows(3 * time.Second)
+
+	if got := len(primary.received()); got != 5 {
+		t.Errorf("principal recebeu %d notificações, esperado 5", got)
+	}
+	if got := len(shadow.received()); got != 2 {
+		t.Errorf("shadow recebeu %d notificações, esperado 2", got)
+	}
+	if got := len(reports); got != 2 {
+		t.Errorf("%d comparações, esperado 2", got)
+	}
+	if got := c.shadowInFlight.Load(); got != 0 {
+		t.Errorf("%d envios shadow em andamento após o término", got)
+	}
+}
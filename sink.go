@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sink é um destino de notificações, como o serviço gRPC, um webhook ou um arquivo de auditoria
+type Sink interface {
+	// Name identifica o sink no Router e nos resultados de Router.Send
+	Name() string
+
+	// Send entrega uma notificação já validada
+	Send(ctx context.Context, params *Data) (*Result, error)
+}
+
+// grpcSink entrega as notificações pelo serviço gRPC de um NotifyClient
+type grpcSink struct {
+	client *NotifyClient
+}
+
+// NewGRPCSink retorna um Sink chamado "grpc" que envia as notificações por NotifyWithResult,
+// com as mesmas retentativas, enrichers e middlewares do cliente
+func NewGRPCSink(client *NotifyClient) Sink {
+	return grpcSink{client: client}
+}
+
+func (s grpcSink) Name() string {
+	return "grpc"
+}
+
+func (s grpcSink) Send(ctx context.Context, params *Data) (*Result, error) {
+	return s.client.NotifyWithResult(ctx, params)
+}
+
+// funcSink adapta uma função para a interface Sink
+type funcSink struct {
+	name string
+	fn   func(ctx context.Context, params *Data) (*Result, error)
+}
+
+// SinkFunc retorna um Sink com o nome informado que entrega as notificações chamando fn
+func SinkFunc(name string, fn func(ctx context.Context, params *Data) (*Result, error)) Sink {
+	return funcSink{name: name, fn: fn}
+}
+
+func (s funcSink) Name() string {
+	return s.name
+}
+
+func (s funcSink) Send(ctx context.Context, params *Data) (*Result, error) {
+	return s.fn(ctx, params)
+}
+
+// FileSink grava as notificações em um arquivo JSON Lines, como um registro de auditoria.
+// O arquivo pode ser lido por NewJSONLReader e reenviado com notify replay.
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewFileSink abre o arquivo informado para acrescentar notificações, criando-o se não existir
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir arquivo de notificações: %w", err)
+	}
+	return &FileSink{path: path, file: file}, nil
+}
+
+// Name retorna "file:" seguido do caminho do arquivo
+func (s *FileSink) Name() string {
+	return "file:" + s.path
+}
+
+// Send acrescenta a notificação ao arquivo, em uma linha
+func (s *FileSink) Send(ctx context.Context, params *Data) (*Result, error) {
+	content, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao codificar notificação: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(content, '\n')); err != nil {
+		return nil, fmt.Errorf("falha ao gravar notificação em %s: %w", s.path, err)
+	}
+	return &Result{CreatedAt: time.Now(), Attempts: 1}, nil
+}
+
+// Close fecha o arquivo
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
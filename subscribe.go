@@ -0,0 +1,187 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"github.com/getsentry/sentry-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Intervalos mínimo e máximo entre tentativas de reconexão de uma assinatura
+const (
+	minSubscribeBackoff = 100 * time.Millisecond
+	maxSubscribeBackoff = 30 * time.Second
+)
+
+// SubscribeFilter define quais notificações uma assinatura recebe. Campos vazios não restringem o resultado.
+type SubscribeFilter struct {
+	ProjectID string
+	Scope     string
+
+	// Cursor a partir do qual a assinatura começa; vazio recebe apenas notificações novas
+	Cursor string
+}
+
+// Subscription é uma assinatura criada por Subscribe
+type Subscription struct {
+	ch   chan *Notification
+	done chan struct{}
+	err  error
+}
+
+// Notifications retorna o canal em que as notificações são entregues. Ele é fechado quando a
+// assinatura termina; Err informa o motivo.
+func (s *Subscription) Notifications() <-chan *Notification {
+	return s.ch
+}
+
+// Err retorna o erro que encerrou a assinatura, como Unimplemented quando o servidor não
+// suporta assinaturas ou ErrClientClosed quando o cliente foi fechado. Retorna nil enquanto a assinatura está ativa ou quando ela foi
+// encerrada pelo cancelamento do contexto.
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Subscribe assina as novas notificações que atendem ao filtro e as entrega em
+// Subscription.Notifications. Se a conexão cair, a assinatura é refeita automaticamente a
+// partir do último evento recebido, com backoff exponencial a partir de RetryInterval (no mínimo
+// 100ms), e o erro de conexão é enviado ao Sentry. A assinatura termina quando o contexto é
+// cancelado, quando o cliente é fechado ou quando o servidor não suporta assinaturas; nos dois
+// últimos casos, o erro fica disponível em Subscription.Err.
+// O transporte em processo não suporta assinaturas.
+func (c *NotifyClient) Subscribe(ctx context.Context, filter SubscribeFilter) (*Subscription, error) {
+	if filter.Scope != "" && !slices.Contains(Scopes(), filter.Scope) {
+		return nil, fmt.Errorf("filtro inválido: invalid scope: %s. Use one of the constants: %s", filter.Scope, strings.Join(Scopes(), ", "))
+	}
+
+	select {
+	case <-c.closed:
+		return nil, ErrClientClosed
+	default:
+	}
+
+	sub := &Subscription{
+		ch:   make(chan *Notification),
+		done: make(chan struct{}),
+	}
+	go c.subscribe(ctx, filter, sub)
+	return sub, nil
+}
+
+// subscribe mantém a assinatura ativa até o contexto ser cancelado, o cliente ser fechado ou o
+// servidor recusá-la
+func (c *NotifyClient) subscribe(ctx context.Context, filter SubscribeFilter, sub *Subscription) {
+	// O erro é registrado antes de o canal ser fechado, para que Err já o retorne quando o
+	// chamador perceber o fechamento
+	defer close(sub.ch)
+	defer close(sub.done)
+
+	// Fechar o cliente interrompe o stream em andamento e a espera entre reconexões
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// ended indica se a assinatura deve terminar, registrando ErrClientClosed se o cliente foi fechado
+	ended := func() bool {
+		select {
+		case <-c.closed:
+			sub.err = ErrClientClosed
+			return true
+		default:
+			return ctx.Err() != nil
+		}
+	}
+
+	req := &notifications.SubscribeRequest{
+		ProjectId: filter.ProjectID,
+		Scope:     filter.Scope,
+		Cursor:    filter.Cursor,
+	}
+
+	var backoff time.Duration
+	for {
+		received, err := c.receive(ctx, req, sub.ch)
+		if ended() {
+			return
+		}
+
+		// Servidor sem suporte a assinaturas: não adianta tentar de novo
+		if status.Code(err) == codes.Unimplemented {
+			sub.err = fmt.Errorf("assinatura de notificações não suportada (endpoint %s): %w", c.ActiveEndpoint(), err)
+			return
+		}
+
+		// Captura erro no Sentry, se configurado
+		sentry.CaptureException(fmt.Errorf("assinatura de notificações interrompida (endpoint %s): %w", c.ActiveEndpoint(), err))
+
+		// Volta ao intervalo inicial se a conexão chegou a entregar eventos. O mínimo evita
+		// reconexões sem pausa quando RetryInterval é zero.
+		retryInterval := max(c.state.Load().options.RetryInterval, minSubscribeBackoff)
+		if received || backoff == 0 {
+			backoff = retryInterval
+		} else {
+			backoff = min(backoff*2, maxSubscribeBackoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			ended()
+			return
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// receive abre um stream e repassa os eventos até ele terminar, atualizando o cursor da requisição.
+// Retorna se algum evento foi recebido e o erro que encerrou o stream.
+func (c *NotifyClient) receive(ctx context.Context, req *notifications.SubscribeRequest, ch chan<- *Notification) (bool, error) {
+	// Usa a configuração atual a cada reconexão, para refletir recarregamentos
+	state := c.state.Load()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var p peer.Peer
+	stream, err := state.client.Subscribe(ctx, req, state.options.callOptions(req, &p)...)
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+	for {
+		event, err := stream.Recv()
+		if p.Addr != nil {
+			c.setActiveEndpoint(state.options, p.Addr.String())
+		}
+		if err != nil {
+			return received, err
+		}
+
+		received = true
+		req.Cursor = event.GetCursor()
+
+		select {
+		case ch <- newNotification(event.GetNotification()):
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
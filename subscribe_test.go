@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AdSeleto/notify/pb/notifications"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubscribeUnimplementedErr(t *testing.T) {
+	captured := captureSentry(t)
+	c := newTestClient(t, &fakeServer{})
+
+	sub, err := c.Subscribe(context.Background(), SubscribeFilter{ProjectID: "p1"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	select {
+	case _, ok := <-sub.Notifications():
+		if ok {
+			t.Fatal("notificação inesperada")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("canal não foi fechado")
+	}
+	if code := status.Code(sub.Err()); code != codes.Unimplemented {
+		t.Errorf("Err() = %v, esperado código Unimplemented", sub.Err())
+	}
+	if messages := captured(); len(messages) > 0 {
+		t.Errorf("erros enviados ao Sentry: %v", messages)
+	}
+}
+
+// subscribingServer entrega um evento a cada assinatura e mantém o stream aberto
+type subscribingServer struct {
+	fakeServer
+}
+
+func (s *subscribingServer) Subscribe(req *notifications.SubscribeRequest, stream grpc.ServerStreamingServer[notifications.SubscribeEvent]) error {
+	event := &notifications.SubscribeEvent{
+		Notification: &notifications.Notification{Id: "n1", ProjectId: req.GetProjectId()},
+		Cursor:       "c1",
+	}
+	if err := stream.Send(event); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func TestSubscribeCanceledErr(t *testing.T) {
+	c, err := NewClient(WithServerAddress(startServer(t, &subscribingServer{})), WithOrigin("test"), WithRetryInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub, err := c.Subscribe(ctx, SubscribeFilter{ProjectID: "p1"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	select {
+	case n := <-sub.Notifications():
+		if n == nil || n.ID != "n1" {
+			t.Fatalf("notificação %+v, esperado n1", n)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("nenhuma notificação recebida")
+	}
+	if err := sub.Err(); err != nil {
+		t.Errorf("Err() com a assinatura ativa = %v, esperado nil", err)
+	}
+
+	cancel()
+	for range sub.Notifications() {
+	}
+	if err := sub.Err(); err != nil {
+		t.Errorf("Err() após o cancelamento = %v, esperado nil", err)
+	}
+}
+
+// receiveAll aguarda o canal da assinatura ser fechado, descartando as notificações
+func receiveAll(t *testing.T, sub *Subscription) {
+	t.Helper()
+
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case _, ok := <-sub.Notifications():
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("canal não foi fechado")
+		}
+	}
+}
+
+func TestSubscribeEndsOnClose(t *testing.T) {
+	captured := captureSentry(t)
+	c, err := NewClient(WithServerAddress(startServer(t, &subscribingServer{})), WithOrigin("test"), WithRetryInterval(0))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	sub, err := c.Subscribe(context.Background(), SubscribeFilter{ProjectID: "p1"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if n := <-sub.Notifications(); n == nil {
+		t.Fatal("nenhuma notificação recebida")
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	receiveAll(t, sub)
+	if err := sub.Err(); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Err() = %v, esperado ErrClientClosed", err)
+	}
+	if messages := captured(); len(messages) > 0 {
+		t.Errorf("erros enviados ao Sentry após Close: %v", messages)
+	}
+
+	if _, err := c.Subscribe(context.Background(), SubscribeFilter{}); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Subscribe após Close: %v, esperado ErrClientClosed", err)
+	}
+}
+
+// unavailableServer recusa toda assinatura com Unavailable, contando as tentativas
+type unavailableServer struct {
+	fakeServer
+	subscribes atomic.Int32
+}
+
+func (s *unavailableServer) Subscribe(req *notifications.SubscribeRequest, stream grpc.ServerStreamingServer[notifications.SubscribeEvent]) error {
+	s.subscribes.Add(1)
+	return status.Error(codes.Unavailable, "fora do ar")
+}
+
+func TestSubscribeZeroIntervalBackoff(t *testing.T) {
+	captured := captureSentry(t)
+	server := &unavailableServer{}
+	c, err := NewClient(WithServerAddress(startServer(t, server)), WithOrigin("test"), WithRetryInterval(0))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := c.Subscribe(ctx, SubscribeFilter{ProjectID: "p1"})
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Com backoff de 100ms, 200ms, 400ms..., são no máximo 3 tentativas em 500ms
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	receiveAll(t, sub)
+
+	if got := server.subscribes.Load(); got < 2 || got > 4 {
+		t.Errorf("%d tentativas de assinatura em 500ms, esperado entre 2 e 4", got)
+	}
+	if got := len(captured()); got > 4 {
+		t.Errorf("%d erros enviados ao Sentry em 500ms, esperado no máximo 4", got)
+	}
+}
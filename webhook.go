@@ -0,0 +1,367 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Cabeçalhos das requisições do WebhookSink
+const (
+	// Assinatura HMAC-SHA256 de "<timestamp>.<corpo>", no formato "sha256=<hex>"
+	WebhookSignatureHeader = "X-Notify-Signature"
+
+	// Momento do envio da tentativa, em segundos desde a época Unix
+	WebhookTimestampHeader = "X-Notify-Timestamp"
+
+	// Identificador da entrega, o mesmo em todas as tentativas, para deduplicação pelo destino
+	WebhookDeliveryHeader = "X-Notify-Delivery"
+)
+
+// WebhookPayload é o corpo JSON enviado pelo WebhookSink
+type WebhookPayload struct {
+	ID         string         `json:"id"`
+	Origin     string         `json:"origin"`
+	ProjectID  string         `json:"project_id"`
+	Scope      string         `json:"scope"`
+	Type       string         `json:"type"`
+	Severity   string         `json:"severity"`
+	Metadata   map[string]any `json:"metadata"`
+	DedupKey   string         `json:"dedup_key,omitempty"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	SentAt     time.Time      `json:"sent_at"`
+}
+
+// DeadLetter é uma notificação que o WebhookSink não conseguiu entregar
+type DeadLetter struct {
+	// Nome do sink e URL de destino
+	Sink string
+	URL  string
+
+	// Notificação e corpo JSON da última tentativa
+	Params *Data
+	Body   []byte
+
+	// Tentativas feitas e erro da última delas
+	Attempts int
+	Err      error
+
+	// Momento da desistência
+	FailedAt time.Time
+}
+
+// DeadLetterHandler recebe as notificações que esgotaram as tentativas de entrega
+type DeadLetterHandler func(ctx context.Context, letter *DeadLetter)
+
+// WebhookSink entrega notificações por POST de um JSON assinado a uma URL HTTP,
+// para parceiros e ferramentas que não falam gRPC
+type WebhookSink struct {
+	name       string
+	url        string
+	secret     []byte
+	origin     string
+	client     *http.Client
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	deadLetter DeadLetterHandler
+}
+
+// WebhookOption é um tipo para funções de configuração do WebhookSink
+type WebhookOption func(*WebhookSink)
+
+// NewWebhookSink cria um sink que envia as notificações para a URL informada, assinadas com
+// o segredo compartilhado. Cada URL é um sink; use um Router para entregar a vários destinos.
+func NewWebhookSink(rawURL, secret string, opts ...WebhookOption) (*WebhookSink, error) {
+	s := &WebhookSink{
+		name:       "webhook:" + rawURL,
+		url:        rawURL,
+		secret:     []byte(secret),
+		client:     &http.Client{Timeout: time.Second * 10},
+		maxRetries: 3,
+		backoff:    time.Millisecond * 500,
+		maxBackoff: time.Second * 30,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// validate verifica a configuração e reporta todos os problemas encontrados de uma vez
+func (s *WebhookSink) validate() error {
+	var errs []error
+
+	if u, err := url.Parse(s.url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("URL do webhook inválida: %q. Use uma URL http:// ou https://", s.url))
+	}
+	if len(s.secret) == 0 {
+		errs = append(errs, fmt.Errorf("o segredo do webhook não pode ser vazio"))
+	}
+	if s.origin == "" {
+		errs = append(errs, fmt.Errorf("a origem (Origin) do webhook deve ser configurada usando WithWebhookOrigin()"))
+	}
+	if s.client == nil {
+		errs = append(errs, fmt.Errorf("o cliente HTTP do webhook não pode ser nulo"))
+	}
+	if s.maxRetries < 0 {
+		errs = append(errs, fmt.Errorf("o número máximo de tentativas do webhook não pode ser negativo"))
+	}
+	if s.backoff <= 0 || s.maxBackoff < s.backoff {
+		errs = append(errs, fmt.Errorf("o intervalo entre tentativas do webhook deve ser maior que zero e não pode passar do máximo"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// WithWebhookOrigin define a origem/serviço que está enviando a notificação (obrigatório)
+func WithWebhookOrigin(origin string) WebhookOption {
+	return func(s *WebhookSink) {
+		s.origin = origin
+	}
+}
+
+// WithWebhookName substitui o nome padrão do sink, "webhook:" seguido da URL
+func WithWebhookName(name string) WebhookOption {
+	return func(s *WebhookSink) {
+		s.name = name
+	}
+}
+
+// WithWebhookHTTPClient define o cliente HTTP usado nas requisições, para configurar timeouts,
+// proxy, TLS ou usar o cliente de um httptest.Server. O padrão tem timeout de 10 segundos.
+func WithWebhookHTTPClient(client *http.Client) WebhookOption {
+	return func(s *WebhookSink) {
+		s.client = client
+	}
+}
+
+// WithWebhookMaxRetries define o número máximo de retentativas depois da primeira tentativa
+func WithWebhookMaxRetries(retries int) WebhookOption {
+	return func(s *WebhookSink) {
+		s.maxRetries = retries
+	}
+}
+
+// WithWebhookBackoff define o intervalo antes da primeira retentativa, dobrado a cada nova
+// tentativa até o máximo informado
+func WithWebhookBackoff(initial, maxDelay time.Duration) WebhookOption {
+	return func(s *WebhookSink) {
+		s.backoff = initial
+		s.maxBackoff = maxDelay
+	}
+}
+
+// WithWebhookDeadLetter define quem recebe as notificações que esgotaram as tentativas,
+// como um FileSink para reenvio posterior
+func WithWebhookDeadLetter(handler DeadLetterHandler) WebhookOption {
+	return func(s *WebhookSink) {
+		s.deadLetter = handler
+	}
+}
+
+// Name retorna o nome do sink
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+// Send envia a notificação, com retentativas em falhas de rede, respostas 5xx e 429.
+// Outras respostas 4xx não são repetidas. Quando a entrega falha, a notificação é entregue
+// ao DeadLetterHandler, se configurado.
+func (s *WebhookSink) Send(ctx context.Context, params *Data) (*Result, error) {
+	payload, err := params.toWebhookPayload(s.origin, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("parâmetros inválidos: %w", err)
+	}
+
+	var body []byte
+	var lastErr error
+	attempt := 0
+	for ; attempt <= s.maxRetries; attempt++ {
+		payload.SentAt = time.Now().UTC()
+		if body, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("falha ao codificar notificação: %w", err)
+		}
+
+		var retryAfter time.Duration
+		var retry bool
+		retryAfter, retry, lastErr = s.post(ctx, payload.ID, body)
+		if lastErr == nil {
+			return &Result{ID: payload.ID, CreatedAt: payload.SentAt, Attempts: attempt + 1}, nil
+		}
+		if !retry || attempt == s.maxRetries {
+			break
+		}
+
+		if err := sleepContext(ctx, max(s.delay(attempt), retryAfter)); err != nil {
+			lastErr = errors.Join(lastErr, err)
+			break
+		}
+	}
+
+	attempts := attempt + 1
+	err = fmt.Errorf("falha ao enviar webhook para %s após %d tentativas: %w", s.url, attempts, lastErr)
+	if s.deadLetter != nil {
+		s.deadLetter(context.WithoutCancel(ctx), &DeadLetter{
+			Sink:     s.name,
+			URL:      s.url,
+			Params:   params,
+			Body:     body,
+			Attempts: attempts,
+			Err:      err,
+			FailedAt: time.Now(),
+		})
+	}
+	return nil, err
+}
+
+// post faz uma tentativa de entrega e indica se ela pode ser repetida e quanto esperar,
+// conforme o cabeçalho Retry-After da resposta
+func (s *WebhookSink) post(ctx context.Context, deliveryID string, body []byte) (time.Duration, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookDeliveryHeader, deliveryID)
+	req.Header.Set(WebhookSignatureHeader, signWebhook(s.secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// Falhas de rede podem ser repetidas, a menos que o contexto tenha sido cancelado
+		return 0, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	// Lê parte da resposta para o erro e descarta o restante, para reaproveitar a conexão
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, false, nil
+	}
+
+	err = fmt.Errorf("resposta %s: %s", resp.Status, bytes.TrimSpace(detail))
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return s.retryAfter(resp.Header.Get("Retry-After")), retry, err
+}
+
+// retryAfter interpreta o cabeçalho Retry-After, em segundos ou como data HTTP,
+// limitado ao intervalo máximo. Valores inválidos ou no passado retornam zero.
+func (s *WebhookSink) retryAfter(value string) time.Duration {
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		delay = time.Until(t)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return min(delay, s.maxBackoff)
+}
+
+// delay retorna o intervalo antes da retentativa seguinte à tentativa informada (a partir de zero)
+func (s *WebhookSink) delay(attempt int) time.Duration {
+	delay := s.backoff
+	for range attempt {
+		delay *= 2
+		if delay >= s.maxBackoff {
+			return s.maxBackoff
+		}
+	}
+	return delay
+}
+
+// sleepContext aguarda o intervalo ou o cancelamento do contexto
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// VerifyWebhookSignature verifica a assinatura de uma requisição do WebhookSink, a partir dos
+// cabeçalhos X-Notify-Timestamp e X-Notify-Signature e do corpo recebido. Com tolerance maior
+// que zero, requisições assinadas há mais tempo que tolerance são rejeitadas, evitando replays.
+func VerifyWebhookSignature(secret, timestamp, signature string, body []byte, tolerance time.Duration) error {
+	if !hmac.Equal([]byte(signature), []byte(signWebhook([]byte(secret), timestamp, body))) {
+		return fmt.Errorf("assinatura do webhook inválida")
+	}
+
+	if tolerance > 0 {
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return fmt.Errorf("timestamp do webhook inválido %q", timestamp)
+		}
+		if age := time.Since(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+			return fmt.Errorf("timestamp do webhook fora da tolerância de %s", tolerance)
+		}
+	}
+	return nil
+}
+
+// signWebhook retorna a assinatura HMAC-SHA256 de "<timestamp>.<corpo>"
+func signWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Converte os parâmetros de notificação para o corpo do webhook, com a mesma validação das
+// requests gRPC. Metadata e StructuredMetadata são reunidos em um único objeto.
+func (np *Data) toWebhookPayload(origin string, now time.Time) (*WebhookPayload, error) {
+	if err := np.Validate(); err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("falha ao gerar o ID da entrega: %w", err)
+	}
+
+	metadata := make(map[string]any, len(np.Metadata)+len(np.StructuredMetadata))
+	for k, v := range np.Metadata {
+		metadata[k] = v
+	}
+	for k, v := range np.StructuredMetadata {
+		metadata[k] = v
+	}
+
+	return &WebhookPayload{
+		ID:         hex.EncodeToString(id),
+		Origin:     origin,
+		ProjectID:  np.ProjectID,
+		Scope:      np.Scope,
+		Type:       np.Type,
+		Severity:   np.severity(),
+		Metadata:   metadata,
+		DedupKey:   np.DedupKey,
+		OccurredAt: np.stamp(now).OccurredAt.UTC(),
+	}, nil
+}
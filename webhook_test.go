@@ -0,0 +1,251 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "segredo"
+
+// webhookRequest é uma requisição recebida pelo servidor de teste
+type webhookRequest struct {
+	header     http.Header
+	body       []byte
+	receivedAt time.Time
+}
+
+// webhookServer responde a cada requisição com respond, que recebe o número da tentativa a
+// partir de 1. Retorna o servidor e uma função que lista as requisições recebidas.
+func webhookServer(t *testing.T, respond func(w http.ResponseWriter, attempt int)) (*httptest.Server, func() []webhookRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []webhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, webhookRequest{header: r.Header.Clone(), body: body, receivedAt: time.Now()})
+		attempt := len(requests)
+		mu.Unlock()
+
+		respond(w, attempt)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []webhookRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookRequest(nil), requests...)
+	}
+}
+
+// newTestWebhook cria um WebhookSink para o servidor de teste, com intervalos curtos
+func newTestWebhook(t *testing.T, server *httptest.Server, opts ...WebhookOption) *WebhookSink {
+	t.Helper()
+
+	opts = append([]WebhookOption{
+		WithWebhookOrigin("test"),
+		WithWebhookHTTPClient(server.Client()),
+		WithWebhookBackoff(time.Millisecond, 10*time.Millisecond),
+	}, opts...)
+	sink, err := NewWebhookSink(server.URL, testWebhookSecret, opts...)
+	if err != nil {
+		t.Fatalf("NewWebhookSink: %v", err)
+	}
+	return sink
+}
+
+func TestWebhookSignature(t *testing.T) {
+	server, requests := webhookServer(t, func(w http.ResponseWriter, attempt int) {})
+	sink := newTestWebhook(t, server)
+
+	params := testData("p1")
+	params.Metadata = map[string]string{"campaign": "c1"}
+	result, err := sink.Send(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	received := requests()
+	if len(received) != 1 {
+		t.Fatalf("%d requisições, esperado 1", len(received))
+	}
+	req := received[0]
+	timestamp, signature := req.header.Get(WebhookTimestampHeader), req.header.Get(WebhookSignatureHeader)
+
+	if err := VerifyWebhookSignature(testWebhookSecret, timestamp, signature, req.body, time.Minute); err != nil {
+		t.Fatalf("VerifyWebhookSignature: %v", err)
+	}
+	if err := VerifyWebhookSignature("outro-segredo", timestamp, signature, req.body, time.Minute); err == nil {
+		t.Error("assinatura aceita com outro segredo")
+	}
+	if err := VerifyWebhookSignature(testWebhookSecret, timestamp, signature, append(req.body, ' '), time.Minute); err == nil {
+		t.Error("assinatura aceita com o corpo alterado")
+	}
+
+	// Uma requisição antiga, corretamente assinada, é rejeitada fora da tolerância
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	if err := VerifyWebhookSignature(testWebhookSecret, old, signWebhook([]byte(testWebhookSecret), old, req.body), req.body, time.Minute); err == nil {
+		t.Error("assinatura antiga aceita fora da tolerância")
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("corpo inválido: %v", err)
+	}
+	if payload.ID != result.ID || req.header.Get(WebhookDeliveryHeader) != result.ID {
+		t.Errorf("ID da entrega: payload %q, cabeçalho %q, resultado %q", payload.ID, req.header.Get(WebhookDeliveryHeader), result.ID)
+	}
+	if payload.Origin != "test" || payload.ProjectID != "p1" || payload.Metadata["campaign"] != "c1" {
+		t.Errorf("payload inesperado: %+v", payload)
+	}
+}
+
+func TestWebhookRetries5xx(t *testing.T) {
+	server, requests := webhookServer(t, func(w http.ResponseWriter, attempt int) {
+		if attempt < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	sink := newTestWebhook(t, server)
+
+	result, err := sink.Send(context.Background(), testData("p1"))
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if result.Attempts != 3 {
+		t.Errorf("Attempts = %d, esperado 3", result.Attempts)
+	}
+
+	// Todas as tentativas têm o mesmo ID de entrega, para deduplicação pelo destino
+	for i, req := range requests() {
+		if id := req.header.Get(WebhookDeliveryHeader); id != result.ID {
+			t.Errorf("tentativa %d: ID da entrega %q, esperado %q", i+1, id, result.ID)
+		}
+	}
+}
+
+func TestWebhookRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"segundos", "1"},
+		{"data HTTP", time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := webhookServer(t, func(w http.ResponseWriter, attempt int) {
+				if attempt == 1 {
+					w.Header().Set("Retry-After", tt.value)
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+			})
+			// Retry-After é limitado ao intervalo máximo, para que o teste não espere o valor informado
+			sink := newTestWebhook(t, server, WithWebhookBackoff(time.Millisecond, 100*time.Millisecond))
+
+			result, err := sink.Send(context.Background(), testData("p1"))
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			if result.Attempts != 2 {
+				t.Fatalf("Attempts = %d, esperado 2", result.Attempts)
+			}
+
+			received := requests()
+			if gap := received[1].receivedAt.Sub(received[0].receivedAt); gap < 100*time.Millisecond || gap > time.Second {
+				t.Errorf("intervalo entre tentativas de %s, esperado o Retry-After limitado a 100ms", gap)
+			}
+		})
+	}
+}
+
+func TestWebhookRetryAfterValues(t *testing.T) {
+	sink := &WebhookSink{maxBackoff: time.Minute}
+
+	tests := []struct {
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{"", 0, 0},
+		{"30", 30 * time.Second, 30 * time.Second},
+		{"-5", 0, 0},
+		{"600", time.Minute, time.Minute},
+		{"amanhã", 0, 0},
+		{time.Now().Add(20 * time.Second).UTC().Format(http.TimeFormat), 15 * time.Second, 20 * time.Second},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, 0},
+		{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), time.Minute, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := sink.retryAfter(tt.value); got < tt.min || got > tt.max {
+			t.Errorf("retryAfter(%q) = %s, esperado entre %s e %s", tt.value, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestWebhookNonRetryable4xx(t *testing.T) {
+	server, requests := webhookServer(t, func(w http.ResponseWriter, attempt int) {
+		http.Error(w, "payload recusado", http.StatusBadRequest)
+	})
+	var letters []*DeadLetter
+	sink := newTestWebhook(t, server, WithWebhookDeadLetter(func(ctx context.Context, letter *DeadLetter) {
+		letters = append(letters, letter)
+	}))
+
+	if _, err := sink.Send(context.Background(), testData("p1")); err == nil {
+		t.Fatal("esperado erro para a resposta 400")
+	}
+	if got := len(requests()); got != 1 {
+		t.Errorf("%d requisições, esperado 1 sem retentativas", got)
+	}
+	if len(letters) != 1 || letters[0].Attempts != 1 {
+		t.Errorf("dead letters: %+v, esperado uma com 1 tentativa", letters)
+	}
+}
+
+func TestWebhookDeadLetter(t *testing.T) {
+	server, requests := webhookServer(t, func(w http.ResponseWriter, attempt int) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	var letters []*DeadLetter
+	sink := newTestWebhook(t, server,
+		WithWebhookName("parceiro"),
+		WithWebhookMaxRetries(2),
+		WithWebhookDeadLetter(func(ctx context.Context, letter *DeadLetter) {
+			letters = append(letters, letter)
+		}),
+	)
+
+	params := testData("p1")
+	_, err := sink.Send(context.Background(), params)
+	if err == nil {
+		t.Fatal("esperado erro depois de esgotar as tentativas")
+	}
+	received := requests()
+	if len(received) != 3 {
+		t.Fatalf("%d requisições, esperado 3", len(received))
+	}
+
+	if len(letters) != 1 {
+		t.Fatalf("%d dead letters, esperado 1", len(letters))
+	}
+	letter := letters[0]
+	if letter.Sink != "parceiro" || letter.URL != server.URL || letter.Attempts != 3 || letter.Params != params {
+		t.Errorf("dead letter inesperada: %+v", letter)
+	}
+	if string(letter.Body) != string(received[2].body) {
+		t.Errorf("corpo da dead letter difere do corpo da última tentativa")
+	}
+	if letter.Err == nil || letter.Err.Error() != err.Error() {
+		t.Errorf("erro da dead letter %v, esperado %v", letter.Err, err)
+	}
+}